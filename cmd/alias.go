@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/DMXMax/mythic-cli/util/input"
+	"github.com/DMXMax/mythic-cli/util/shortcut"
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd lists the shell aliases. Its subcommands define and remove them.
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage shell aliases",
+	Long: `Aliases are short names for a command line. Typing an alias runs its command line
+with any further arguments appended, e.g. after 'alias set rl roll -o likely',
+'rl Is it locked?' runs 'roll -o likely Is it locked?'.
+
+The command line may use positional parameters: $1 to $9 are replaced by the
+arguments after the alias and $@ by all of them. Aliases that use parameters do
+not get the arguments appended.
+
+Aliases are saved in ~/.mythic-db/shortcuts.json. Without a subcommand, lists all aliases.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := shortcut.Load()
+		if err != nil {
+			return err
+		}
+		if len(s.Aliases) == 0 {
+			cmd.Println("No aliases defined. Use 'alias set <name> <command line>' to add one.")
+			return nil
+		}
+		for _, name := range sortedKeys(s.Aliases) {
+			cmd.Printf("%s = %s\n", name, s.Aliases[name])
+		}
+		return nil
+	},
+}
+
+// aliasSetCmd defines or replaces an alias.
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <command line>",
+	Short: "Define an alias",
+	Long: `Define an alias, replacing any alias or macro with the same name.
+Quote arguments that contain spaces, e.g. alias set nc roll -o "nearly certain"`,
+	Args: cobra.MinimumNArgs(2),
+	// Flags belong to the aliased command line
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("alias set requires a name and a command line")
+		}
+		s, err := loadForDefine(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		s.Aliases[args[0]] = input.JoinArgs(args[1:])
+		if err := s.Save(); err != nil {
+			return err
+		}
+		cmd.Printf("%s = %s\n", args[0], s.Aliases[args[0]])
+		return nil
+	},
+}
+
+// aliasRemoveCmd removes an alias.
+var aliasRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm", "delete"},
+	Short:   "Remove an alias",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("alias remove requires a name")
+		}
+		s, err := shortcut.Load()
+		if err != nil {
+			return err
+		}
+		if _, ok := s.Aliases[args[0]]; !ok {
+			return fmt.Errorf("alias %q not found", args[0])
+		}
+		delete(s.Aliases, args[0])
+		if err := s.Save(); err != nil {
+			return err
+		}
+		cmd.Printf("Removed alias: %s\n", args[0])
+		return nil
+	},
+}
+
+// macroCmd lists the shell macros. Its subcommands define, show and remove them.
+var macroCmd = &cobra.Command{
+	Use:   "macro",
+	Short: "Manage shell macros",
+	Long: `Macros are named sequences of commands. Typing a macro's name runs its commands in
+order and stops at the first one that fails.
+
+Each command is given as one quoted argument and may use positional parameters:
+$1 to $9 are replaced by the arguments after the macro name and $@ by all of them, e.g.
+
+  macro set open "scene start $@" "descriptor actions" "gamelog add Scene opened: $@"
+  open The tavern at night
+
+Macros are saved in ~/.mythic-db/shortcuts.json. Without a subcommand, lists all macros.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := shortcut.Load()
+		if err != nil {
+			return err
+		}
+		if len(s.Macros) == 0 {
+			cmd.Println("No macros defined. Use 'macro set <name> <command>...' to add one.")
+			return nil
+		}
+		for _, name := range sortedKeys(s.Macros) {
+			printMacro(cmd, name, s.Macros[name])
+		}
+		return nil
+	},
+}
+
+// macroSetCmd defines or replaces a macro.
+var macroSetCmd = &cobra.Command{
+	Use:   "set <name> <command>...",
+	Short: "Define a macro",
+	Long: `Define a macro, replacing any alias or macro with the same name.
+Each command of the macro is one argument, so quote commands that contain spaces.`,
+	Args: cobra.MinimumNArgs(2),
+	// Flags belong to the macro's commands
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("macro set requires a name and at least one command")
+		}
+		for _, line := range args[1:] {
+			if _, err := input.SplitArgs(line); err != nil {
+				return fmt.Errorf("invalid command %q: %w", line, err)
+			}
+		}
+		s, err := loadForDefine(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		s.Macros[args[0]] = args[1:]
+		if err := s.Save(); err != nil {
+			return err
+		}
+		printMacro(cmd, args[0], s.Macros[args[0]])
+		return nil
+	},
+}
+
+// macroShowCmd prints the commands of a macro.
+var macroShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the commands of a macro",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("macro show requires a name")
+		}
+		s, err := shortcut.Load()
+		if err != nil {
+			return err
+		}
+		lines, ok := s.Macros[args[0]]
+		if !ok {
+			return fmt.Errorf("macro %q not found", args[0])
+		}
+		printMacro(cmd, args[0], lines)
+		return nil
+	},
+}
+
+// macroRemoveCmd removes a macro.
+var macroRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm", "delete"},
+	Short:   "Remove a macro",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("macro remove requires a name")
+		}
+		s, err := shortcut.Load()
+		if err != nil {
+			return err
+		}
+		if _, ok := s.Macros[args[0]]; !ok {
+			return fmt.Errorf("macro %q not found", args[0])
+		}
+		delete(s.Macros, args[0])
+		if err := s.Save(); err != nil {
+			return err
+		}
+		cmd.Printf("Removed macro: %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd, aliasRemoveCmd)
+	macroCmd.AddCommand(macroSetCmd, macroShowCmd, macroRemoveCmd)
+}
+
+// loadForDefine loads the shortcuts for defining name, which must not be a shell command.
+// Any existing alias or macro called name is dropped so the new definition replaces it.
+func loadForDefine(cmd *cobra.Command, name string) (*shortcut.Shortcuts, error) {
+	if sub := findSubcommand(shellCmd, name); sub != nil {
+		return nil, fmt.Errorf("%q is a command and cannot be redefined", name)
+	}
+	s, err := shortcut.Load()
+	if err != nil {
+		return nil, err
+	}
+	delete(s.Aliases, name)
+	delete(s.Macros, name)
+	return s, nil
+}
+
+// printMacro prints a macro's name followed by its numbered commands.
+func printMacro(cmd *cobra.Command, name string, lines []string) {
+	cmd.Printf("%s:\n", name)
+	for i, line := range lines {
+		cmd.Printf("  %d. %s\n", i+1, line)
+	}
+}
+
+// sortedKeys returns the keys of m in alphabetical order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// addCmd adds a new character to the current game's Characters List.
+var addCmd = &cobra.Command{
+	Use:     "add <name>",
+	Aliases: []string{"a", "new"},
+	Short:   "Add a character to the Characters List",
+	Long: `Add a new character to the Characters List of the current game.
+Use --description to attach a short description and --weight to add it more than once (1-3).`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		name := strings.TrimSpace(strings.Join(args, " "))
+		if name == "" {
+			return fmt.Errorf("character name cannot be empty")
+		}
+
+		weight, err := cmd.Flags().GetInt("weight")
+		if err != nil {
+			return fmt.Errorf("failed to get weight flag: %w", err)
+		}
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return fmt.Errorf("failed to get description flag: %w", err)
+		}
+
+		character, err := gdb.AddCharacter(g.ID, name, strings.TrimSpace(description), weight)
+		if err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Character added: %s", character.Name)
+		if weight > 1 {
+			msg = fmt.Sprintf("%s (x%d)", msg, weight)
+		}
+		if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+			return fmt.Errorf("failed to log character change: %w", err)
+		}
+
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	addCmd.Flags().StringP("description", "d", "", "optional description of the character")
+	addCmd.Flags().IntP("weight", "w", 1, "number of times the character appears on the list (1-3)")
+	CharacterCmd.AddCommand(addCmd)
+}
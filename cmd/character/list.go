@@ -0,0 +1,75 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// listCmd lists the characters of the current game.
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l", "ls"},
+	Short:   "List the Characters List",
+	Long: `List the characters of the current game with their list number, weight and notes.
+Retired characters are hidden unless --all is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("failed to get all flag: %w", err)
+		}
+		return runList(cmd, all)
+	},
+}
+
+func init() {
+	listCmd.Flags().BoolP("all", "a", false, "include retired characters")
+	CharacterCmd.AddCommand(listCmd)
+}
+
+// runList prints the characters of the current game. List numbers always refer to
+// the position among all characters so that they stay stable when filtering.
+func runList(cmd *cobra.Command, all bool) error {
+	g := gdb.Current
+	if g == nil {
+		return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+	}
+
+	characters, err := gdb.GetCharacters(g.ID, true)
+	if err != nil {
+		return fmt.Errorf("failed to load characters: %w", err)
+	}
+
+	shown := 0
+	for i, c := range characters {
+		if !all && c.Status != gdb.CharacterActive {
+			continue
+		}
+		if shown == 0 {
+			cmd.Println("Characters:")
+		}
+		shown++
+		line := fmt.Sprintf("  %d. %s", i+1, c.Name)
+		if c.Weight > 1 {
+			line = fmt.Sprintf("%s (x%d)", line, c.Weight)
+		}
+		if c.Status != gdb.CharacterActive {
+			line = fmt.Sprintf("%s [%s]", line, c.Status)
+		}
+		cmd.Println(line)
+		if c.Description != "" {
+			cmd.Printf("     %s\n", c.Description)
+		}
+		for _, note := range strings.Split(c.Notes, "\n") {
+			if note != "" {
+				cmd.Printf("     - %s\n", note)
+			}
+		}
+	}
+	if shown == 0 {
+		cmd.Println("No characters on the list.")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// noteCmd appends a note to a character.
+var noteCmd = &cobra.Command{
+	Use:   "note <character> <text>",
+	Short: "Add a note to a character",
+	Long: `Append a note to a character. The character can be given by list number or by name;
+multi-word names are matched against the Characters List, e.g. 'character note Old Tom owes us a favour'.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeCharacters,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		if len(args) < 2 {
+			return fmt.Errorf("note requires a character and a note")
+		}
+
+		c, text, err := splitNoteArgs(g, args)
+		if err != nil {
+			return err
+		}
+
+		notes := text
+		if c.Notes != "" {
+			notes = c.Notes + "\n" + text
+		}
+		if err := db.GamesDB.Model(c).Update("notes", notes).Error; err != nil {
+			return fmt.Errorf("failed to update character: %w", err)
+		}
+
+		msg := fmt.Sprintf("Character note: %s - %s", c.Name, text)
+		if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+			return fmt.Errorf("failed to log character change: %w", err)
+		}
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	CharacterCmd.AddCommand(noteCmd)
+}
+
+// splitNoteArgs separates the character from the note text by matching progressively
+// shorter argument sequences against the full names on the Characters List.
+// If no full name matches, the first argument is used as the character reference.
+func splitNoteArgs(g *gdb.Game, args []string) (*gdb.Character, string, error) {
+	characters, err := gdb.GetCharacters(g.ID, true)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load characters: %w", err)
+	}
+	for i := len(args) - 1; i > 0; i-- {
+		candidate := strings.Join(args[:i], " ")
+		for _, c := range characters {
+			if strings.EqualFold(c.Name, candidate) {
+				return &c, strings.Join(args[i:], " "), nil
+			}
+		}
+	}
+
+	c, err := gdb.FindCharacter(g.ID, args[0])
+	if err != nil {
+		return nil, "", err
+	}
+	return c, strings.Join(args[1:], " "), nil
+}
@@ -0,0 +1,47 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// removeCmd deletes a character from the Characters List entirely.
+// Use 'character retire' instead to keep the character in the game's history.
+var removeCmd = &cobra.Command{
+	Use:               "remove <character>",
+	Aliases:           []string{"rm", "delete"},
+	Short:             "Remove a character from the list",
+	Long:              `Remove a character from the Characters List entirely. Use 'character retire' to keep it in the game's history instead.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeCharacters,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		c, err := gdb.FindCharacter(g.ID, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if err := db.GamesDB.Delete(c).Error; err != nil {
+			return fmt.Errorf("failed to remove character: %w", err)
+		}
+
+		msg := fmt.Sprintf("Character removed: %s", c.Name)
+		if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+			return fmt.Errorf("failed to log character change: %w", err)
+		}
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	CharacterCmd.AddCommand(removeCmd)
+}
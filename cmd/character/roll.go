@@ -0,0 +1,43 @@
+package character
+
+import (
+	"fmt"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// rollCmd picks a random character from the Characters List, honouring weights.
+var rollCmd = &cobra.Command{
+	Use:     "roll",
+	Aliases: []string{"r", "pick"},
+	Short:   "Roll on the Characters List",
+	Long: `Pick a random active character from the Characters List. Characters with a higher
+weight occupy more entries and are more likely to be picked. The result is recorded in the game log.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		c, err := gdb.RollCharacter(g.ID)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			cmd.Println("The Characters List is empty.")
+			return nil
+		}
+
+		msg := fmt.Sprintf("Characters List -> %s", c.Name)
+		if _, err := gdb.AddLog(g, gdb.LogTypeDiceRoll, msg); err != nil {
+			return fmt.Errorf("failed to log character roll: %w", err)
+		}
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	CharacterCmd.AddCommand(rollCmd)
+}
@@ -0,0 +1,49 @@
+// Package character provides commands for managing the Mythic Characters List of a game.
+package character
+
+import (
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// CharacterCmd is the root command for Characters List management.
+// When invoked without subcommands, it lists the active characters of the current game.
+var CharacterCmd = &cobra.Command{
+	Use:     "character",
+	Aliases: []string{"characters", "char", "npc"},
+	Short:   "Manage the Characters List",
+	Long: `Manage the Characters List of the current game. Characters are the important NPCs
+of the adventure. A character can appear on the list up to 3 times (its weight),
+which makes it more likely to be picked when rolling on the list.
+
+Characters can be referred to by their list number (see 'character list --all') or by name.
+Every change is recorded in the game log.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runList(cmd, false)
+	},
+}
+
+// completeCharacters completes the character argument of a command with the names
+// on the current game's Characters List.
+func completeCharacters(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 || gdb.Current == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	characters, err := gdb.GetCharacters(gdb.Current.ID, true)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]cobra.Completion, 0, len(characters))
+	for _, c := range characters {
+		names = append(names, c.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWeight completes a character followed by a weight.
+func completeWeight(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeCharacters(cmd, args, toComplete)
+	}
+	return []cobra.Completion{"1", "2", "3"}, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,63 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// retireCmd marks a character as inactive, removing it from the active Characters List.
+var retireCmd = &cobra.Command{
+	Use:               "retire <character>",
+	Aliases:           []string{"inactive"},
+	Short:             "Retire a character from the list",
+	Long:              `Mark a character as inactive. Retired characters stay in the game's history but are no longer part of the active Characters List.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeCharacters,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatus(cmd, strings.Join(args, " "), gdb.CharacterInactive, "Character retired")
+	},
+}
+
+// activateCmd returns a retired character to the active Characters List.
+var activateCmd = &cobra.Command{
+	Use:               "activate <character>",
+	Aliases:           []string{"reactivate"},
+	Short:             "Return a retired character to the list",
+	Long:              `Return a retired character to the active Characters List.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeCharacters,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatus(cmd, strings.Join(args, " "), gdb.CharacterActive, "Character returned")
+	},
+}
+
+func init() {
+	CharacterCmd.AddCommand(retireCmd)
+	CharacterCmd.AddCommand(activateCmd)
+}
+
+// setStatus changes the status of the referenced character and logs the change.
+func setStatus(cmd *cobra.Command, ref, status, label string) error {
+	g := gdb.Current
+	if g == nil {
+		return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+	}
+
+	c, err := gdb.FindCharacter(g.ID, ref)
+	if err != nil {
+		return err
+	}
+	if err := gdb.SetCharacterStatus(c, status); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("%s: %s", label, c.Name)
+	if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+		return fmt.Errorf("failed to log character change: %w", err)
+	}
+	cmd.Println(msg)
+	return nil
+}
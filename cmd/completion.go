@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/input"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// shellCompleter returns a liner word completer for the interactive shell.
+// It walks the command tree below root to complete command names, flag names and
+// flag values, and uses the commands' ValidArgsFunction (the same functions that
+// drive 'mythic-cli completion') and ValidArgs for arguments such as game, thread or table names.
+func shellCompleter(root *cobra.Command) func(line string, pos int) (string, []string, string) {
+	return func(line string, pos int) (string, []string, string) {
+		before, tail := line[:pos], line[pos:]
+
+		start := wordStart(before)
+		words, err := input.SplitArgs(before[:start])
+		if err != nil {
+			return before, nil, tail
+		}
+		partial := unquotePartial(before[start:])
+
+		candidates := completeWords(root, words, partial)
+		completions := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			if strings.HasPrefix(strings.ToLower(c), strings.ToLower(partial)) {
+				completions = append(completions, input.QuoteArg(c)+" ")
+			}
+		}
+		return before[:start], completions, tail
+	}
+}
+
+// completeWords returns the candidates for the word following words.
+func completeWords(root *cobra.Command, words []string, partial string) []string {
+	// Find the deepest command named by the leading words
+	c := root
+	i := 0
+	for ; i < len(words); i++ {
+		sub := findSubcommand(c, words[i])
+		if sub == nil {
+			break
+		}
+		c = sub
+	}
+
+	// Split the remaining words into positional arguments and flags,
+	// noting whether the last word is a flag still waiting for its value
+	var args []string
+	var pending *pflag.Flag
+	dashdash := false
+	for _, w := range words[i:] {
+		switch {
+		case pending != nil:
+			pending = nil
+		case dashdash || w == "-" || !strings.HasPrefix(w, "-"):
+			args = append(args, w)
+		case w == "--":
+			dashdash = true
+		case !strings.Contains(w, "="):
+			pending = lookupFlag(c, w)
+			if pending != nil && pending.NoOptDefVal != "" {
+				pending = nil // boolean flags take no value
+			}
+		}
+	}
+
+	if pending != nil {
+		if fn, ok := c.GetFlagCompletionFunc(pending.Name); ok {
+			values, _ := fn(c, args, partial)
+			return stripDescriptions(values)
+		}
+		return nil
+	}
+
+	if strings.HasPrefix(partial, "-") && !dashdash {
+		var flags []string
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden {
+				flags = append(flags, "--"+f.Name)
+			}
+		})
+		sort.Strings(flags)
+		return append(flags, "--help")
+	}
+
+	var candidates []string
+	if len(args) == 0 {
+		for _, sub := range c.Commands() {
+			if sub.IsAvailableCommand() {
+				candidates = append(candidates, sub.Name())
+			}
+		}
+	}
+	if len(args) == 0 {
+		candidates = append(candidates, stripDescriptions(c.ValidArgs)...)
+	}
+	if c.ValidArgsFunction != nil {
+		values, _ := c.ValidArgsFunction(c, args, partial)
+		candidates = append(candidates, stripDescriptions(values)...)
+	}
+	return candidates
+}
+
+// findSubcommand returns the subcommand of c with the given name or alias, or nil.
+func findSubcommand(c *cobra.Command, name string) *cobra.Command {
+	for _, sub := range c.Commands() {
+		if sub.Name() == name || sub.HasAlias(name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// lookupFlag returns the flag of c named by a "--name" or "-n" word, or nil.
+func lookupFlag(c *cobra.Command, word string) *pflag.Flag {
+	if name, ok := strings.CutPrefix(word, "--"); ok {
+		return c.Flags().Lookup(name)
+	}
+	// For combined short flags ("-xo") the last one takes the value
+	short := word[len(word)-1:]
+	return c.Flags().ShorthandLookup(short)
+}
+
+// stripDescriptions removes the tab-separated descriptions cobra completions may carry.
+func stripDescriptions(values []cobra.Completion) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		name, _, _ := strings.Cut(v, "\t")
+		out = append(out, name)
+	}
+	return out
+}
+
+// wordStart returns the index in line where the word under the cursor starts,
+// honouring quotes and backslash escapes the way input.SplitArgs does.
+func wordStart(line string) int {
+	start := 0
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if r == '\\' && quote == '"' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\\':
+			escaped = true
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ' ' || r == '\t':
+			start = i + 1
+		}
+	}
+	return start
+}
+
+// unquotePartial returns the value of a partially typed word, closing an open quote.
+func unquotePartial(word string) string {
+	word = strings.TrimSuffix(word, "\\")
+	for _, closing := range []string{"", "\"", "'"} {
+		if args, err := input.SplitArgs(word + closing); err == nil {
+			return strings.Join(args, "")
+		}
+	}
+	return word
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/config"
+	"github.com/spf13/cobra"
+)
+
+// configCmd lists the settings of the config file.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change settings",
+	Long: `Show and change the settings kept in ~/.config/mythic-cli/config.toml
+(or $XDG_CONFIG_HOME/mythic-cli/config.toml). Without a subcommand, lists all settings.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return configListCmd.RunE(cmd, args)
+	},
+}
+
+// configListCmd lists every setting with its value and description.
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all settings",
+	Long:    `List all settings with their values. Settings that are not set show their default.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Settings (%s):\n", path)
+		for _, s := range config.Settings {
+			value := strconv.Quote(cfg.Value(s.Name))
+			if _, ok := cfg.Get(s.Name); !ok {
+				value += " (default)"
+			}
+			cmd.Printf("  %-16s %s\n", s.Name, value)
+			cmd.Printf("  %-16s %s\n", "", s.Description)
+		}
+		return nil
+	},
+}
+
+// configGetCmd prints the value of a setting.
+var configGetCmd = &cobra.Command{
+	Use:               "get <setting>",
+	Short:             "Show the value of a setting",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettings,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := config.Lookup(args[0]); err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		cmd.Println(cfg.Value(args[0]))
+		return nil
+	},
+}
+
+// configSetCmd changes a setting.
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "Change a setting",
+	Long: `Change a setting. Values with spaces can be quoted or given as several words,
+e.g. config set default_odds very likely. Setting db works like 'db use': the database
+is opened first and remembered by its name or full path.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeSettings,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := config.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		value := strings.Join(args[1:], " ")
+		if s.Name == config.DB {
+			// A database is only remembered once it opens, as with 'db use'
+			return dbUseCmd.RunE(cmd, []string{value})
+		}
+		if s.Validate != nil {
+			if err := s.Validate(value); err != nil {
+				return err
+			}
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		cfg.Set(s.Name, value)
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		cmd.Printf("%s = %q\n", s.Name, value)
+		return nil
+	},
+}
+
+// configUnsetCmd resets a setting to its default.
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset <setting>",
+	Aliases:           []string{"reset"},
+	Short:             "Reset a setting to its default",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettings,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := config.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		cfg.Unset(s.Name)
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		cmd.Printf("%s = %q (default)\n", s.Name, s.Default)
+		return nil
+	},
+}
+
+// completeSettings completes the name of a setting.
+func completeSettings(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]cobra.Completion, len(config.Settings))
+	for i, s := range config.Settings {
+		names[i] = s.Name
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd, configGetCmd, configSetCmd, configUnsetCmd)
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/config"
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// dbEnv is the environment variable that selects the database.
+const dbEnv = "MYTHIC_DB"
+
+// dbFlag holds the --db flag: the database to use for this invocation only.
+var dbFlag string
+
+// databasePath returns the database selected by the --db flag, the MYTHIC_DB
+// environment variable or the config file, in that order, falling back to
+// ~/.mythic-db/games.db. source describes where the choice came from.
+func databasePath() (path, source string, err error) {
+	name, source := db.DefaultName, "default"
+	if dbFlag != "" {
+		name, source = dbFlag, "--db flag"
+	} else if env := os.Getenv(dbEnv); env != "" {
+		name, source = env, dbEnv
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return "", "", err
+		}
+		if v, ok := cfg.Get(config.DB); ok && v != "" {
+			name, source = v, "config"
+		}
+	}
+	path, err = db.Resolve(name)
+	return path, source, err
+}
+
+// openDatabase opens the selected database unless it is already open.
+func openDatabase() error {
+	path, _, err := databasePath()
+	if err != nil {
+		return err
+	}
+	if db.GamesDB != nil && db.Path == path {
+		return nil
+	}
+	return gdb.OpenDatabase(path)
+}
+
+// worksWithoutDatabase reports whether cmd can run when the selected database fails
+// to open. The config commands and 'db use' are how a broken selection is fixed, and
+// 'db list' shows what there is to choose from.
+func worksWithoutDatabase(cmd *cobra.Command) bool {
+	return cmd == dbUseCmd || cmd == dbListCmd || cmd == configCmd || cmd.Parent() == configCmd
+}
+
+// dbCmd shows the database in use.
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Show or switch the game database",
+	Long: `Show the game database in use. Games are kept in SQLite database files, by default
+~/.mythic-db/games.db. Several databases, e.g. one per table group, can be kept side by side
+and switched between with 'db use'.
+
+The database is chosen by the --db flag, then the MYTHIC_DB environment variable,
+then the db setting in the config file (which 'db use' changes).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, source, err := databasePath()
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Database: %s (%s)\n", db.Path, source)
+		return nil
+	},
+}
+
+// dbListCmd lists the databases in ~/.mythic-db.
+var dbListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the game databases",
+	Long:    `List the databases in ~/.mythic-db. The one in use is marked with *.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := listDatabases()
+		if err != nil {
+			return err
+		}
+		dir, _ := db.Dir()
+		for _, p := range paths {
+			mark := " "
+			if p == db.Path {
+				mark = "*"
+			}
+			name := p
+			if filepath.Dir(p) == dir {
+				name = strings.TrimSuffix(filepath.Base(p), ".db")
+			}
+			cmd.Printf("%s %s\n", mark, name)
+		}
+		return nil
+	},
+}
+
+// dbUseCmd switches to another database and remembers the choice.
+var dbUseCmd = &cobra.Command{
+	Use:   "use <name|path>",
+	Short: "Switch to another game database",
+	Long: `Switch to another game database and remember the choice in the config file.
+A plain name such as "horror" refers to ~/.mythic-db/horror.db; a path names any file.
+The database is created if it does not exist yet.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeDatabases,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := db.Resolve(args[0])
+		if err != nil {
+			return err
+		}
+		_, statErr := os.Stat(path)
+
+		// Open the database before remembering it, so a file that is not a game
+		// database never becomes the one every later invocation fails to open
+		if err := gdb.OpenDatabase(path); err != nil {
+			return err
+		}
+		if err := gdb.RestoreCurrent(); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		value := path
+		if dir, _ := db.Dir(); filepath.Dir(path) == dir {
+			value = strings.TrimSuffix(filepath.Base(path), ".db")
+		}
+		cfg.Set(config.DB, value)
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		if errors.Is(statErr, os.ErrNotExist) {
+			cmd.Printf("Created database %s\n", path)
+		}
+		cmd.Printf("Using database %s\n", path)
+		if dbFlag != "" || os.Getenv(dbEnv) != "" {
+			cmd.Printf("Note: the --db flag or %s still takes precedence in other invocations\n", dbEnv)
+		}
+		return nil
+	},
+}
+
+// listDatabases returns the database files in ~/.mythic-db, together with the
+// database in use if it lives elsewhere, sorted by path.
+func listDatabases() ([]string, error) {
+	dir, err := db.Dir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	if db.Path != "" && filepath.Dir(db.Path) != dir {
+		paths = append(paths, db.Path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// completeDatabases completes the names of the databases in ~/.mythic-db.
+func completeDatabases(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	dir, err := db.Dir()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.db"))
+	names := make([]cobra.Completion, len(paths))
+	for i, p := range paths {
+		names[i] = strings.TrimSuffix(filepath.Base(p), ".db")
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	dbCmd.AddCommand(dbListCmd, dbUseCmd)
+}
@@ -1,10 +1,12 @@
 package descriptor
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/DMXMax/mge/util/elements"
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +18,11 @@ var listCmd = &cobra.Command{
 	Long:    `Lists all available Elements Meaning Tables that can be used for generating descriptors.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		tables := getAvailableTables()
+
+		if output.IsJSON() {
+			sort.Strings(tables)
+			return output.Print(cmd.OutOrStdout(), map[string][]string{"tables": slices.Compact(tables)})
+		}
 		
 		// Sort for consistent output
 		sort.Strings(tables)
@@ -228,3 +235,13 @@ func isTableAvailable(tableName string) bool {
 	}
 }
 
+
+// completeTables completes the table argument of the descriptor command.
+func completeTables(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tables := getAvailableTables()
+	sort.Strings(tables)
+	return slices.Compact(tables), cobra.ShellCompDirectiveNoFileComp
+}
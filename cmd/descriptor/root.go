@@ -6,9 +6,16 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
+// descriptorOutput is the JSON form of generated descriptors.
+type descriptorOutput struct {
+	Table   string   `json:"table"`
+	Entries []string `json:"entries"`
+}
+
 // DescriptorCmd is the root command for descriptor generation.
 var DescriptorCmd = &cobra.Command{
 	Use:   "descriptor [type] [number]",
@@ -18,6 +25,7 @@ for describing characters, locations, objects, and other game elements.
 
 Use 'descriptor list' to see all available descriptor tables.
 Use 'descriptor <type> [number]' to generate descriptors from a specific table.`,
+	ValidArgsFunction: completeTables,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no args, show help
 		if len(args) == 0 {
@@ -71,6 +79,10 @@ Use 'descriptor <type> [number]' to generate descriptors from a specific table.`
 			return fmt.Errorf("failed to generate entries from table '%s'", tableType)
 		}
 		
+		if output.IsJSON() {
+			return output.Print(cmd.OutOrStdout(), descriptorOutput{Table: tableType, Entries: entries})
+		}
+
 		// Display results
 		for i, entry := range entries {
 			cmd.Println(entry)
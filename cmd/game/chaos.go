@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	"github.com/DMXMax/mge/chart"
-	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
 	"github.com/spf13/cobra"
 )
@@ -34,16 +33,10 @@ var chaosCmd = &cobra.Command{
 			}
 			// Convert user input (1-9) to internal representation (0-8)
 			internalChaos := int8(chart.ChaosUserToInternal(userChaos))
-			g.SetChaos(internalChaos)
-			fmt.Printf("Chaos factor set to %d\n", userChaos)
-			// Persist the change to the database
-			// Use Select() to only update chaos field, avoiding association saves
-			// This prevents duplicate log entries if Log field is populated
-			if err := db.GamesDB.Model(g).Select("chaos", "updated_at").Updates(map[string]interface{}{
-				"chaos": internalChaos,
-			}).Error; err != nil {
+			if err := gdb.SaveChaos(g, internalChaos); err != nil {
 				return fmt.Errorf("failed to save game after changing chaos: %w", err)
 			}
+			fmt.Printf("Chaos factor set to %d\n", userChaos)
 			return nil
 		}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/DMXMax/mge/chart"
 	"github.com/DMXMax/mge/storage"
 	"github.com/DMXMax/mge/util/theme"
+	"github.com/DMXMax/mythic-cli/util/config"
 	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
 	"github.com/rs/zerolog/log"
@@ -25,7 +26,7 @@ var createCmd = &cobra.Command{
 	Long: `Create a new game with a supplied name. If a game with that name already exists,
 it will be selected and set as the current game instead of creating a duplicate.
 
-The chaos factor can be specified with the --chaos or -x flag (default: 5).
+The chaos factor can be specified with the --chaos or -x flag (default: the default_chaos setting, 5).
 Valid chaos factor range is 1-9.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate required arguments
@@ -46,6 +47,18 @@ Valid chaos factor range is 1-9.`,
 		if err != nil {
 			return fmt.Errorf("failed to get chaos flag: %w", err)
 		}
+		// Without the flag, use the default_chaos setting (5 unless configured)
+		if !cmd.Flags().Changed("chaos") {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			defaultChaos, err := cfg.Int(config.DefaultChaos)
+			if err != nil {
+				return err
+			}
+			userChaos = int8(defaultChaos)
+		}
 
 		// Validate chaos factor range (user-facing: 1-9)
 		if userChaos < chart.MinChaosUser || userChaos > chart.MaxChaosUser {
@@ -62,7 +75,9 @@ Valid chaos factor range is 1-9.`,
 		switch err := result.Error; {
 		case err == nil:
 			// Game found, set it as current
-			gdb.Current = &game
+			if err := gdb.SetCurrent(&game); err != nil {
+				return err
+			}
 			log.Info().Str("game", name).Msg("Selected existing game")
 			cmd.Printf("Game '%s' already exists - loaded existing game (Chaos: %d)\n", name, chart.ChaosInternalToUser(int(game.Chaos)))
 		case errors.Is(err, gorm.ErrRecordNotFound):
@@ -77,7 +92,9 @@ Valid chaos factor range is 1-9.`,
 			if err := db.GamesDB.Create(newGame).Error; err != nil {
 				return fmt.Errorf("failed to create game '%s': %w", name, err)
 			}
-			gdb.Current = newGame
+			if err := gdb.SetCurrent(newGame); err != nil {
+				return err
+			}
 			log.Info().Str("game", name).Int8("chaos", chaos).Msg("Created new game")
 			cmd.Printf("Created new game: %s (Chaos: %d)\n", name, chart.ChaosInternalToUser(int(newGame.Chaos)))
 		default:
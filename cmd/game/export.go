@@ -5,34 +5,56 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
-	"time"
 
-	"github.com/DMXMax/mge/chart"
 	"github.com/DMXMax/mge/storage"
+	"github.com/DMXMax/mythic-cli/util/config"
 	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/export"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
 	"github.com/DMXMax/mythic-cli/util/input"
 	"github.com/spf13/cobra"
 )
 
-// defaultTemplatePath is the default path for the game export template.
-const defaultTemplatePath = "data/templates/game.md.tmpl"
+// Export formats
+const (
+	exportMarkdown = "markdown"
+	exportJSON     = "json"
+	exportHTML     = "html"
+)
 
 var (
-	exportTemplatePath string
-	exportOutPath      string
-	exportForce        bool
+	exportTemplatePath  string
+	exportOutPath       string
+	exportForce         bool
+	exportFormat        string
+	exportListTemplates bool
 )
 
-// exportCmd exports a game to a Markdown file using a Go text/template.
+// exportCmd exports a game to a Markdown file using a Go text/template, or to a JSON
+// document that 'game import' can read back.
 // If no game name is provided, the current game is exported.
 // The export includes all game data and log entries formatted according to the template.
 var exportCmd = &cobra.Command{
 	Use:   "export [name]",
-	Short: "export a game to Markdown",
-	Long:  "Export a game to a Markdown file using a Go text/template file. If no name is provided, the current game is exported.",
+	Short: "export a game to Markdown, HTML or JSON",
+	Long: `Export a game to a Markdown file using a Go text/template. If no name is provided, the current game is exported.
+
+Choose the template with --template: the name of a built-in or user template, or the path of
+a template file. Built-in styles include the full log, story-only prose, a scene-by-scene
+journal and a rolls appendix; 'game export --list-templates' shows them all. Templates saved
+as ~/.config/mythic-cli/templates/<name>.md.tmpl add to the built-in ones or replace them.
+
+With --format html, the game is written as a single web page with inline CSS (<game>.html)
+that can be opened in any browser; see 'game publish' for a site with all games.
+
+With --format json, the whole game (settings, story themes, log, scenes, threads and characters)
+is written as a versioned JSON document that 'game import' recreates, e.g. on another machine.`,
+	ValidArgsFunction: completeGames,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportListTemplates {
+			return listTemplates(cmd)
+		}
+
 		// Determine target game name
 		var name string
 		if len(args) > 0 {
@@ -51,77 +73,52 @@ var exportCmd = &cobra.Command{
 			return fmt.Errorf("failed to load game '%s': %w", name, err)
 		}
 
-		// Load log entries separately, ordered chronologically (oldest first)
-		// Use DISTINCT to avoid duplicates (in case they exist in the database)
-		var logEntries []gdb.LogEntry
-		if err := db.GamesDB.Where("game_id = ?", game.ID).Order("created_at ASC").Find(&logEntries).Error; err != nil {
-			return fmt.Errorf("failed to load log entries: %w", err)
+		switch exportFormat {
+		case exportMarkdown, exportHTML:
+		case exportJSON:
+			return exportGameJSON(cmd, &game)
+		default:
+			return fmt.Errorf("unknown export format: %q (use %s, %s or %s)", exportFormat, exportMarkdown, exportHTML, exportJSON)
 		}
 
-		// Deduplicate by content and timestamp (in case duplicates exist in the database)
-		// This handles cases where the same entry was saved multiple times with different IDs
-		seen := make(map[string]bool)
-		uniqueEntries := []gdb.LogEntry{}
-		for _, entry := range logEntries {
-			// Create a unique key from message, type, and timestamp (to the second)
-			key := fmt.Sprintf("%s|%d|%s", entry.Msg, entry.Type, entry.CreatedAt.Format("2006-01-02 15:04:05"))
-			if !seen[key] {
-				seen[key] = true
-				uniqueEntries = append(uniqueEntries, entry)
-			}
+		// Load the game's scenes, lists, log and statistics for the template
+		data, err := export.NewGame(&game)
+		if err != nil {
+			return err
 		}
-		game.Log = uniqueEntries
 
-		// Resolve output path
-		outPath := exportOutPath
-		if strings.TrimSpace(outPath) == "" {
-			safe := storage.SanitizeFilename(game.Name)
-			outPath = safe + ".md"
-		}
-		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil && filepath.Dir(outPath) != "." {
-			return fmt.Errorf("failed to create output directory: %w", err)
+		if exportFormat == exportHTML {
+			return exportGameHTML(cmd, &game, data)
 		}
 
-		// Parse template
-		tplPath := exportTemplatePath
-		if strings.TrimSpace(tplPath) == "" {
-			tplPath = defaultTemplatePath
-		}
-		funcMap := template.FuncMap{
-			"formatTime": func(t time.Time, layout string) string { return t.Format(layout) },
-			"oddsName": func(v int8) string {
-				if v < 0 || int(v) >= len(chart.OddsStrList) {
-					return fmt.Sprintf("%d", v)
-				}
-				return chart.OddsStrList[v]
-			},
-		}
-		tpl, err := template.New(filepath.Base(tplPath)).Funcs(funcMap).ParseFiles(tplPath)
-		if err != nil {
-			return fmt.Errorf("failed to parse template '%s': %w", tplPath, err)
-		}
+		// Resolve output path
+		outPath := exportPath(&game, ".md")
 
-		// If the output file exists, prompt to overwrite
-		if info, err := os.Stat(outPath); err == nil && !info.IsDir() && !exportForce {
-			ans, err := input.Ask(fmt.Sprintf("File '%s' already exists. Overwrite? [y/N]: ", outPath))
+		// Load the template: the --template flag, else the export_template setting
+		tplRef := exportTemplatePath
+		if strings.TrimSpace(tplRef) == "" {
+			cfg, err := config.Load()
 			if err != nil {
-				return fmt.Errorf("failed to read confirmation: %w", err)
+				return err
 			}
-			a := strings.TrimSpace(strings.ToLower(ans))
-			if a != "y" && a != "yes" {
-				return fmt.Errorf("export canceled; file exists: %s", outPath)
+			if tplRef, err = cfg.File(config.ExportTemplate); err != nil {
+				return err
 			}
 		}
+		tpl, err := export.Load(tplRef)
+		if err != nil {
+			return err
+		}
 
-		// Create output file
-		f, err := os.Create(outPath)
+		// Create output file, prompting before overwriting an existing one
+		f, err := createExportFile(outPath)
 		if err != nil {
-			return fmt.Errorf("failed to create output file '%s': %w", outPath, err)
+			return err
 		}
 		defer f.Close()
 
-		// Execute template with game as root
-		if err := tpl.Execute(f, game); err != nil {
+		// Execute template with the game as root
+		if err := tpl.Execute(f, data); err != nil {
 			return fmt.Errorf("failed to render template: %w", err)
 		}
 
@@ -130,8 +127,117 @@ var exportCmd = &cobra.Command{
 	},
 }
 
+// listTemplates prints the built-in and user templates.
+func listTemplates(cmd *cobra.Command) error {
+	templates, err := export.List()
+	if err != nil {
+		return err
+	}
+	dir, err := export.UserDir()
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Templates (user templates in %s):\n", dir)
+	for _, t := range templates {
+		source := t.Source
+		if t.Overrides {
+			source += ", replaces built-in"
+		}
+		cmd.Printf("  %-10s %-26s %s\n", t.Name, "("+source+")", t.Description)
+	}
+	return nil
+}
+
+// completeTemplates completes the names of the export templates.
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	templates, err := export.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	names := make([]cobra.Completion, len(templates))
+	for i, t := range templates {
+		names[i] = cobra.CompletionWithDesc(t.Name, t.Description)
+	}
+	// Template files can be given by path as well
+	return names, cobra.ShellCompDirectiveDefault
+}
+
+// exportGameJSON writes a game as a JSON document for 'game import'.
+func exportGameJSON(cmd *cobra.Command, game *gdb.Game) error {
+	archive, err := gdb.ExportGame(game)
+	if err != nil {
+		return err
+	}
+	outPath := exportPath(game, ".json")
+	f, err := createExportFile(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := archive.Write(f); err != nil {
+		return err
+	}
+	cmd.Printf("Exported game '%s' to %s\n", game.Name, outPath)
+	return nil
+}
+
+// exportGameHTML writes a game as a single HTML page.
+func exportGameHTML(cmd *cobra.Command, game *gdb.Game, data *export.Game) error {
+	outPath := exportPath(game, ".html")
+	f, err := createExportFile(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := export.WriteHTML(f, data); err != nil {
+		return err
+	}
+	cmd.Printf("Exported game '%s' to %s\n", game.Name, outPath)
+	return nil
+}
+
+// exportPath returns the --out path, or a file named after the game with the given extension.
+func exportPath(game *gdb.Game, ext string) string {
+	if strings.TrimSpace(exportOutPath) != "" {
+		return exportOutPath
+	}
+	return storage.SanitizeFilename(game.Name) + ext
+}
+
+// createExportFile creates the output file and its directory. If the file exists,
+// it asks before overwriting it unless --force was given.
+func createExportFile(outPath string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil && filepath.Dir(outPath) != "." {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// If the output file exists, prompt to overwrite
+	if info, err := os.Stat(outPath); err == nil && !info.IsDir() && !exportForce {
+		ans, err := input.Ask(fmt.Sprintf("File '%s' already exists. Overwrite? [y/N]: ", outPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		a := strings.TrimSpace(strings.ToLower(ans))
+		if a != "y" && a != "yes" {
+			return nil, fmt.Errorf("export canceled; file exists: %s", outPath)
+		}
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file '%s': %w", outPath, err)
+	}
+	return f, nil
+}
+
 func init() {
-	exportCmd.Flags().StringVarP(&exportTemplatePath, "template", "t", defaultTemplatePath, "path to the Markdown template file")
-	exportCmd.Flags().StringVarP(&exportOutPath, "out", "o", "", "output Markdown file path (default: <game>.md)")
+	exportCmd.Flags().StringVarP(&exportTemplatePath, "template", "t", "", "template name or file (default: the export_template setting)")
+	exportCmd.Flags().BoolVar(&exportListTemplates, "list-templates", false, "list the available templates")
+	exportCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+	exportCmd.Flags().StringVarP(&exportOutPath, "out", "o", "", "output file path (default: <game>.md, <game>.html or <game>.json)")
 	exportCmd.Flags().BoolVarP(&exportForce, "force", "f", false, "overwrite output file without prompting")
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportMarkdown, "export format: markdown, html or json")
+	exportCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{exportMarkdown, exportHTML, exportJSON}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
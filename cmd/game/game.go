@@ -4,7 +4,9 @@ package game
 
 import (
 	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/oracle"
 	"github.com/spf13/cobra"
 )
 
@@ -42,10 +44,34 @@ func init() {
 	GameCmd.AddCommand(createCmd)
 	GameCmd.AddCommand(saveCmd)
 	GameCmd.AddCommand(chaosCmd)
+	GameCmd.AddCommand(oracleCmd)
 	GameCmd.AddCommand(loadCmd)
 	GameCmd.AddCommand(gameListCmd)
 	GameCmd.AddCommand(removeCmd)
 	GameCmd.AddCommand(exportCmd)
+	GameCmd.AddCommand(importCmd)
+	GameCmd.AddCommand(publishCmd)
+	GameCmd.AddCommand(vaultCmd)
 	GameCmd.AddCommand(infoCmd)
 	GameCmd.AddCommand(plotPointCmd)
 }
+
+// completeGames completes a game name argument with the games in the database.
+func completeGames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []cobra.Completion
+	if err := db.GamesDB.Model(&gdb.Game{}).Order("name").Pluck("name", &names).Error; err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeOracles completes an oracle name argument.
+func completeOracles(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return oracle.Names(), cobra.ShellCompDirectiveNoFileComp
+}
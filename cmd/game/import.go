@@ -0,0 +1,67 @@
+package game
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/DMXMax/mge/storage"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+var importName string
+
+// importCmd recreates a game from a JSON document written by 'game export --format json'.
+// If a game of the same name exists, the imported game is renamed.
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "import a game exported as JSON",
+	Long: `Recreate a game from a file written by 'game export --format json', including its log,
+scenes, threads, characters and settings, and select it as the current game.
+
+If a game of the same name already exists, the imported game is renamed by adding a number,
+e.g. "Kat in Shadow 2". Use --name to choose the name yourself.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open '%s': %w", args[0], err)
+		}
+		defer f.Close()
+		archive, err := gdb.ReadArchive(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		name := archive.Game.Name
+		if cmd.Flags().Changed("name") {
+			name = storage.SanitizeGameName(importName)
+			if free, err := gdb.UniqueGameName(name); err != nil {
+				return err
+			} else if free != name {
+				return fmt.Errorf("game '%s' already exists", name)
+			}
+		} else if name, err = gdb.UniqueGameName(name); err != nil {
+			return err
+		}
+
+		game, err := gdb.ImportGame(archive, name)
+		if err != nil {
+			return err
+		}
+		if err := gdb.SetCurrent(game); err != nil {
+			return err
+		}
+
+		if name != archive.Game.Name && !cmd.Flags().Changed("name") {
+			cmd.Printf("A game named '%s' already exists; importing as '%s'\n", archive.Game.Name, name)
+		}
+		cmd.Printf("Imported game '%s' (%d log entries, %d scenes, %d threads, %d characters)\n",
+			name, len(archive.Log), len(archive.Scenes), len(archive.Threads), len(archive.Characters))
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importName, "name", "n", "", "name for the imported game (default: the exported name)")
+}
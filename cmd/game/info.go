@@ -2,9 +2,11 @@ package game
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
@@ -14,20 +16,25 @@ var infoCmd = &cobra.Command{
 	Use:     "info",
 	Aliases: []string{"i"},
 	Short:   "Display information about the current game",
-	Long:    `Displays the name, themes, and the last 5 log entries for the currently selected game.`,
+	Long:    `Displays the name, themes, active threads and characters, and the last 5 log entries for the currently selected game.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		g := gdb.Current
 		if g == nil {
 			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
 		}
 
-		cmd.Printf("Game: %s\n", g.Name)
-		cmd.Println("Themes:")
-		for _, theme := range g.StoryThemes {
-			cmd.Printf("- %s\n", theme.String())
+		oracle, err := gdb.GameOracle(g.ID)
+		if err != nil {
+			return err
+		}
+		threads, err := gdb.GetThreads(g.ID, false)
+		if err != nil {
+			return fmt.Errorf("failed to load threads: %w", err)
+		}
+		characters, err := gdb.GetCharacters(g.ID, false)
+		if err != nil {
+			return fmt.Errorf("failed to load characters: %w", err)
 		}
-
-		cmd.Println("\nRecent Log Entries:")
 
 		// Fetch the last 5 log entries from the database
 		var entries []gdb.LogEntry
@@ -38,15 +45,76 @@ var infoCmd = &cobra.Command{
 		if err := q.Find(&entries).Error; err != nil {
 			return fmt.Errorf("failed to load log entries: %w", err)
 		}
+		// Show oldest-first for natural reading by reversing the slice
+		slices.Reverse(entries)
 
+		if output.IsJSON() {
+			recent, err := output.Entries(entries)
+			if err != nil {
+				return err
+			}
+			themes := make([]string, len(g.StoryThemes))
+			for i, theme := range g.StoryThemes {
+				themes[i] = theme.String()
+			}
+			return output.Print(cmd.OutOrStdout(), gameInfoOutput{
+				Game:       output.NewGame(g),
+				Oracle:     oracle,
+				Themes:     themes,
+				Threads:    output.Threads(threads),
+				Characters: output.Characters(characters),
+				Recent:     recent,
+			})
+		}
+
+		cmd.Printf("Game: %s\n", g.Name)
+		cmd.Printf("Oracle: %s\n", oracle)
+		cmd.Println("Themes:")
+		for _, theme := range g.StoryThemes {
+			cmd.Printf("- %s\n", theme.String())
+		}
+
+		cmd.Println("\nThreads:")
+		if len(threads) == 0 {
+			cmd.Println("  No active threads.")
+		}
+		for _, t := range threads {
+			if t.Weight > 1 {
+				cmd.Printf("- %s (x%d)\n", t.Name, t.Weight)
+			} else {
+				cmd.Printf("- %s\n", t.Name)
+			}
+		}
+
+		cmd.Println("\nCharacters:")
+		if len(characters) == 0 {
+			cmd.Println("  No active characters.")
+		}
+		for _, c := range characters {
+			if c.Weight > 1 {
+				cmd.Printf("- %s (x%d)\n", c.Name, c.Weight)
+			} else {
+				cmd.Printf("- %s\n", c.Name)
+			}
+		}
+
+		cmd.Println("\nRecent Log Entries:")
 		if len(entries) == 0 {
 			cmd.Println("  No log entries found.")
-		} else {
-			// Print oldest-first for natural reading by reversing the slice
-			for i := len(entries) - 1; i >= 0; i-- {
-				cmd.Printf("- %s\n", entries[i].Msg)
-			}
+		}
+		for _, e := range entries {
+			cmd.Printf("- %s\n", e.Msg)
 		}
 		return nil
 	},
 }
+
+// gameInfoOutput is the JSON form of 'game info'.
+type gameInfoOutput struct {
+	output.Game
+	Oracle     string            `json:"oracle"`
+	Themes     []string          `json:"themes"`
+	Threads    []output.ListItem `json:"threads"`
+	Characters []output.ListItem `json:"characters"`
+	Recent     []output.Entry    `json:"recent_entries"`
+}
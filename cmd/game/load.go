@@ -7,15 +7,17 @@ import (
 	"github.com/DMXMax/mge/chart"
 	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
 // loadCmd loads a game by name and sets it as the current game.
 // The game name can be provided as a positional argument or via the --name flag.
 var loadCmd = &cobra.Command{
-	Use:   "load [name]",
-	Short: "Load a game by name",
-	Long:  `Load a game by name and set it as the current game. You can pass the name as a positional argument or via --name.`,
+	Use:               "load [name]",
+	Short:             "Load a game by name",
+	Long:              `Load a game by name and set it as the current game. You can pass the name as a positional argument or via --name.`,
+	ValidArgsFunction: completeGames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Accept either positional name or --name flag for convenience
 		// Join all args to handle multi-word names (e.g., "Kat in Shadow")
@@ -30,15 +32,14 @@ var loadCmd = &cobra.Command{
 			return fmt.Errorf("no game name specified")
 		}
 
-		g := &gdb.Game{Name: name}
-		result := db.GamesDB.Where(g).First(g)
-
-		if result.Error == nil {
-			gdb.Current = g
-			cmd.Printf("Loaded game: %s (Chaos: %d)\n", g.Name, chart.ChaosInternalToUser(int(g.Chaos)))
-		} else {
-			return fmt.Errorf("could not load game '%s': %w", name, result.Error)
+		g, err := gdb.FindGame(name)
+		if err != nil {
+			return err
+		}
+		if err := gdb.SetCurrent(g); err != nil {
+			return err
 		}
+		cmd.Printf("Loaded game: %s (Chaos: %d)\n", g.Name, chart.ChaosInternalToUser(int(g.Chaos)))
 
 		return nil
 	},
@@ -59,6 +60,14 @@ var gameListCmd = &cobra.Command{
 			return result.Error
 		}
 
+		if output.IsJSON() {
+			out := make([]output.Game, len(games))
+			for i := range games {
+				out[i] = output.NewGame(&games[i])
+			}
+			return output.Print(cmd.OutOrStdout(), out)
+		}
+
 		if len(games) > 0 {
 			fmt.Println("Games Available:")
 		} else {
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+	"github.com/spf13/cobra"
+)
+
+// oracleCmd sets or displays the oracle used to answer yes/no questions in the current game.
+var oracleCmd = &cobra.Command{
+	Use:   "oracle [name]",
+	Short: "Set or show the oracle used by roll",
+	Long: `Set or show the oracle used by 'roll' to answer yes/no questions in the current game.
+Without a name, shows the current oracle and lists the available ones.
+
+The choice is saved with the game. A single roll can use another oracle with 'roll --oracle <name>'.`,
+	ValidArgsFunction: completeOracles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected")
+		}
+
+		if len(args) > 0 {
+			o, err := oracle.Find(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if err := gdb.SetGameOracle(g.ID, o.Name()); err != nil {
+				return err
+			}
+			cmd.Printf("Oracle set to %s\n", o.Name())
+			return nil
+		}
+
+		current, err := gdb.GameOracle(g.ID)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Current Oracle: %s\n", current)
+		cmd.Println("Available oracles:")
+		for _, name := range oracle.Names() {
+			o, _ := oracle.Find(name)
+			cmd.Printf("  %-10s %s\n", name, o.Description())
+		}
+		return nil
+	},
+}
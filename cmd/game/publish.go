@@ -0,0 +1,67 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/export"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/input"
+	"github.com/spf13/cobra"
+)
+
+var publishForce bool
+
+// publishCmd renders all games as a static web site.
+var publishCmd = &cobra.Command{
+	Use:   "publish <dir>",
+	Short: "publish all games as a static web site",
+	Long: `Render all games of the database as a static web site in <dir>: an index of the games and,
+for each game, an overview, the full log, one page per scene, and pages for its threads and
+characters. The pages use the same data as 'game export' and can be opened straight from disk
+or copied to any web server.
+
+If the site exists, it is updated after asking; use --force to update it without asking.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		index := filepath.Join(dir, "index.html")
+		if _, err := os.Stat(index); err == nil && !publishForce {
+			ans, err := input.Ask(fmt.Sprintf("A site already exists in '%s'. Overwrite? [y/N]: ", dir))
+			if err != nil {
+				return fmt.Errorf("failed to read confirmation: %w", err)
+			}
+			a := strings.TrimSpace(strings.ToLower(ans))
+			if a != "y" && a != "yes" {
+				return fmt.Errorf("publish canceled; site exists: %s", dir)
+			}
+		}
+
+		var games []gdb.Game
+		if err := db.GamesDB.Order("name ASC").Find(&games).Error; err != nil {
+			return fmt.Errorf("failed to load games: %w", err)
+		}
+		data := make([]*export.Game, len(games))
+		for i := range games {
+			g, err := export.NewGame(&games[i])
+			if err != nil {
+				return fmt.Errorf("failed to load game '%s': %w", games[i].Name, err)
+			}
+			data[i] = g
+		}
+
+		files, err := export.Publish(dir, data)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Published %d games (%d files) to %s\n", len(games), len(files), index)
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.Flags().BoolVarP(&publishForce, "force", "f", false, "overwrite an existing site without prompting")
+}
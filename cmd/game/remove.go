@@ -15,10 +15,11 @@ var removeName string
 // If the removed game is currently selected, the current game is cleared.
 // The game name can be provided as a positional argument or via the --name flag.
 var removeCmd = &cobra.Command{
-	Use:     "remove [name]",
-	Aliases: []string{"rm", "delete", "del"},
-	Short:   "Remove a game and all its logs",
-	Long:    `Remove a game by name. This also removes all associated log entries. You can pass the name as a positional argument or via --name.`,
+	Use:               "remove [name]",
+	Aliases:           []string{"rm", "delete", "del"},
+	Short:             "Remove a game and all its logs",
+	Long:              `Remove a game by name. This also removes all associated log entries, scenes, threads and characters. You can pass the name as a positional argument or via --name.`,
+	ValidArgsFunction: completeGames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var name string
 		if len(args) > 0 {
@@ -39,12 +40,41 @@ var removeCmd = &cobra.Command{
 		}
 
 		// Delete associated log entries first
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.LogEntryDetail{}).Error; err != nil {
+			return fmt.Errorf("failed to delete log entry details for '%s': %w", name, err)
+		}
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.RollDetail{}).Error; err != nil {
+			return fmt.Errorf("failed to delete roll details for '%s': %w", name, err)
+		}
 		res := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.LogEntry{})
 		if res.Error != nil {
 			return fmt.Errorf("failed to delete log entries for '%s': %w", name, res.Error)
 		}
 		logsRemoved := res.RowsAffected
 
+		// Delete the game's Threads List
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.Thread{}).Error; err != nil {
+			return fmt.Errorf("failed to delete threads for '%s': %w", name, err)
+		}
+
+		// Delete the game's scenes
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.SceneDetail{}).Error; err != nil {
+			return fmt.Errorf("failed to delete scene details for '%s': %w", name, err)
+		}
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.Scene{}).Error; err != nil {
+			return fmt.Errorf("failed to delete scenes for '%s': %w", name, err)
+		}
+
+		// Delete the game's Characters List
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.Character{}).Error; err != nil {
+			return fmt.Errorf("failed to delete characters for '%s': %w", name, err)
+		}
+
+		// Delete the game's settings
+		if err := db.GamesDB.Where("game_id = ?", game.ID).Delete(&gdb.GameDetail{}).Error; err != nil {
+			return fmt.Errorf("failed to delete settings for '%s': %w", name, err)
+		}
+
 		// Delete the game
 		if err := db.GamesDB.Delete(&game).Error; err != nil {
 			return fmt.Errorf("failed to delete game '%s': %w", name, err)
@@ -52,7 +82,9 @@ var removeCmd = &cobra.Command{
 
 		// Clear current selection if it was this game
 		if gdb.Current != nil && gdb.Current.ID == game.ID {
-			gdb.Current = nil
+			if err := gdb.SetCurrent(nil); err != nil {
+				return err
+			}
 		}
 
 		cmd.Printf("Removed game: %s (deleted %d log entries)\n", name, logsRemoved)
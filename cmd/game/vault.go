@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/export"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// vaultCmd writes a game as notes into an Obsidian or Logseq vault.
+// If no game name is provided, the current game is written.
+var vaultCmd = &cobra.Command{
+	Use:   "vault <dir> [name]",
+	Short: "write a game as notes into an Obsidian or Logseq vault",
+	Long: `Write a game as Markdown notes into a folder named after it in the vault <dir>: a campaign
+index note and one note per scene, thread and character, with YAML frontmatter and [[wikilinks]]
+between them. If no name is provided, the current game is written.
+
+Run it again after playing to update the vault. Only the frontmatter properties mythic-cli
+writes and the part of each note between its generated markers are replaced; anything you add
+outside them, such as text under the "Notes" heading or your own properties, is kept; tags and
+aliases you add are kept next to the ones mythic-cli writes.`,
+	Args: cobra.MinimumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		}
+		return completeGames(cmd, args[1:], toComplete)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		var name string
+		if len(args) > 1 {
+			// Join all args to handle multi-word names (e.g., "Kat in Shadow")
+			name = strings.TrimSpace(strings.Join(args[1:], " "))
+		} else if gdb.Current != nil {
+			name = gdb.Current.Name
+		}
+		if name == "" {
+			return fmt.Errorf("no game name specified and no current game selected")
+		}
+
+		var game gdb.Game
+		if err := db.GamesDB.Where("name = ?", name).First(&game).Error; err != nil {
+			return fmt.Errorf("failed to load game '%s': %w", name, err)
+		}
+		data, err := export.NewGame(&game)
+		if err != nil {
+			return err
+		}
+		res, err := export.WriteVault(dir, data)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Wrote game '%s' to %s (%d notes created, %d updated, %d unchanged)\n",
+			game.Name, res.Dir, res.Created, res.Updated, res.Unchanged)
+		return nil
+	},
+}
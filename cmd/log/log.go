@@ -4,11 +4,14 @@ package log
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/DMXMax/mythic-cli/util/config"
 	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +33,7 @@ var LogCmd = &cobra.Command{
 			}
 		}
 		// Default behavior: print logs, optionally limited by a number
-		return runPrint(args)
+		return runPrint(cmd, args)
 	},
 }
 
@@ -49,8 +52,7 @@ var AddGameLogCmd = &cobra.Command{
 		g := gdb.Current
 		msg := strings.Join(args, " ")
 		// Create log entry directly in database to avoid duplicates
-		entry := gdb.LogEntry{Type: gdb.LogTypeStory, Msg: msg, GameID: g.ID}
-		if err := db.GamesDB.Create(&entry).Error; err != nil {
+		if _, err := gdb.AddLog(g, gdb.LogTypeStory, msg); err != nil {
 			return fmt.Errorf("failed to save log entry: %w", err)
 		}
 		fmt.Println("Log entry added and game saved.")
@@ -66,13 +68,14 @@ var printCmd = &cobra.Command{
 	Use:     "print [n]",
 	Aliases: []string{"p", "list", "l"},
 	Short:   "Print recent log entries",
-	Long:    `Print out the story log. Optionally provide a number to print that many recent entries (most recent shown last).`,
+	Long: `Print out the story log. Optionally provide a number to print that many recent entries (most recent shown last).
+Use --scene N to print only the entries recorded during scene N (see 'scene list').`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check if help was requested
 		if len(args) > 0 && (args[0] == "help" || args[0] == "--help" || args[0] == "-h") {
 			return cmd.Help()
 		}
-		return runPrint(args)
+		return runPrint(cmd, args)
 	},
 }
 
@@ -128,7 +131,7 @@ var removeLogCmd = &cobra.Command{
 			fmt.Printf("Cannot remove %d entries, only %d exist. Removing all %d entries.\n", n, numToRemove, numToRemove)
 		}
 
-		if err := db.GamesDB.Delete(&entriesToRemove).Error; err != nil {
+		if err := gdb.DeleteLogEntries(entriesToRemove); err != nil {
 			return fmt.Errorf("failed to remove log entries from database: %w", err)
 		}
 
@@ -140,6 +143,8 @@ var removeLogCmd = &cobra.Command{
 }
 
 func init() {
+	LogCmd.Flags().Int("scene", 0, "only print entries recorded during this scene number")
+	printCmd.Flags().Int("scene", 0, "only print entries recorded during this scene number")
 	LogCmd.AddCommand(AddGameLogCmd)
 	LogCmd.AddCommand(printCmd)
 	LogCmd.AddCommand(removeLogCmd)
@@ -147,16 +152,24 @@ func init() {
 
 // runPrint implements the actual printing logic shared by `log` and `log print`.
 // It fetches the most recent n entries from the database and displays them in chronological order.
-// If args[0] is a positive integer, it prints that many most recent entries; otherwise prints a default number (20).
-func runPrint(args []string) error {
+// If args[0] is a positive integer, it prints that many most recent entries; otherwise prints the
+// number given by the log_page_size setting (20 unless configured).
+// If the --scene flag is set, only entries recorded during that scene are considered.
+func runPrint(cmd *cobra.Command, args []string) error {
 	if gdb.Current == nil {
 		return fmt.Errorf("no game selected")
 	}
 	g := gdb.Current
 
-	// Default to a recent window if no number is specified
-	n := 20
-	var err error
+	// Default to a recent window (the log_page_size setting) if no number is specified
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	n, err := cfg.Int(config.LogPageSize)
+	if err != nil {
+		return err
+	}
 	if len(args) > 0 {
 		n, err = strconv.Atoi(args[0])
 		if err != nil {
@@ -167,24 +180,51 @@ func runPrint(args []string) error {
 		return fmt.Errorf("number of entries to print must be positive")
 	}
 
+	sceneNum, err := cmd.Flags().GetInt("scene")
+	if err != nil {
+		return fmt.Errorf("failed to get scene flag: %w", err)
+	}
+
 	// Fetch the last n entries from DB ordered by newest first
 	var entries []gdb.LogEntry
 	q := db.GamesDB.Model(&gdb.LogEntry{}).
 		Where("game_id = ?", g.ID).
 		Order("created_at DESC").
 		Limit(n)
+	if cmd.Flags().Changed("scene") {
+		s, err := gdb.FindScene(g.ID, sceneNum)
+		if err != nil {
+			return err
+		}
+		q = q.Where("id IN (?)", db.GamesDB.Model(&gdb.LogEntryDetail{}).Select("log_entry_id").Where("scene_id = ?", s.ID))
+	}
 	if err := q.Find(&entries).Error; err != nil {
 		return fmt.Errorf("failed to load log entries: %w", err)
 	}
 
 	// Print oldest-first for natural reading by reversing the slice
-	for i := len(entries) - 1; i >= 0; i-- {
-		s := entries[i]
+	slices.Reverse(entries)
+
+	if output.IsJSON() {
+		out, err := output.Entries(entries)
+		if err != nil {
+			return err
+		}
+		return output.Print(cmd.OutOrStdout(), out)
+	}
+
+	for _, s := range entries {
 		switch s.Type {
 		case gdb.LogTypeSceneStart:
 			fmt.Printf(">>> Scene: %s\n", s.Msg)
 		case gdb.LogTypeSceneEnd:
-			fmt.Printf("<<< Scene End: %s\n", s.Msg)
+			if s.Msg == "" {
+				fmt.Println("<<< Scene End")
+			} else {
+				fmt.Printf("<<< Scene End: %s\n", s.Msg)
+			}
+		case gdb.LogTypeEvent:
+			fmt.Printf("%s - Random Event: %s\n", s.CreatedAt.Format("2006-01-02 15:04:05"), s.Msg)
 		default:
 			fmt.Printf("%s - %s\n", s.CreatedAt.Format("2006-01-02 15:04:05"), s.Msg)
 		}
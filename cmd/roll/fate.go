@@ -3,10 +3,12 @@ package roll
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/DMXMax/mge/util/dice"
-	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/output"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
@@ -60,43 +62,66 @@ Use the --opposed flag to make the difficulty an opposed roll (adds 4dF to the d
 
 		rollTotal := fateRoll.Total()
 		message := strings.Join(args, " ")
-		var logMessage string
 
-		rollStr := fateRoll.String()
+		// The log message is rendered from the structured roll
+		roll := &gdb.RollDetail{
+			Kind:     gdb.RollKindFate,
+			Question: message,
+			Dice:     fateDice(fateRoll),
+			Total:    rollTotal,
+		}
+		out := fateOutput{
+			Question:  message,
+			Dice:      roll.Dice,
+			DiceTotal: fateRoll.DiceTotal(),
+			Total:     rollTotal,
+		}
+
 		if cmd.Flags().Changed("skill") {
-			rollStr = fmt.Sprintf("%s; skill %d -> %d", rollStr, skill, rollTotal)
+			roll.Skill = &skill
+			out.Skill = &skill
 		}
 
 		if cmd.Flags().Changed("difficulty") {
-			finalDifficulty := difficulty
-			var outcome int
+			roll.Difficulty = &difficulty
+			roll.Target = difficulty
 			if isOpposed {
 				opposedRoll := dice.RollFate()
-				finalDifficulty = difficulty + opposedRoll.Total()
-				outcome = rollTotal - finalDifficulty
-				opponentStr := fmt.Sprintf("%s; skill %d -> %d", opposedRoll.String(), difficulty, finalDifficulty)
-				rollStr = fmt.Sprintf("%s vs Opponent (%s): %s (%+d)", rollStr, opponentStr, getOutcomeString(outcome), outcome)
-			} else {
-				outcome = rollTotal - difficulty
-				rollStr = fmt.Sprintf("%s vs diff %d: %s (%+d)", rollStr, difficulty, getOutcomeString(outcome), outcome)
+				roll.OpponentDice = fateDice(opposedRoll)
+				roll.Target = difficulty + opposedRoll.Total()
+				out.Opponent = &fateOutput{
+					Dice:      roll.OpponentDice,
+					DiceTotal: opposedRoll.DiceTotal(),
+					Skill:     &difficulty,
+					Total:     roll.Target,
+				}
 			}
+			outcome := roll.Shifts()
+			roll.Answer = getOutcomeString(outcome)
+			out.Difficulty = &roll.Target
+			out.Outcome = roll.Answer
+			out.Shifts = &outcome
 		}
 
-		if strings.TrimSpace(message) != "" {
-			logMessage = fmt.Sprintf("%s | 4dF %s", message, rollStr)
-		} else {
-			logMessage = fmt.Sprintf("4dF %s", rollStr)
-		}
+		logMessage := roll.String()
+		out.Message = logMessage
 
-		fmt.Println(logMessage)
+		if !output.IsJSON() {
+			fmt.Println(logMessage)
+		}
 
 		if gdb.Current != nil {
-			entry := gdb.LogEntry{Type: gdb.LogTypeDiceRoll, Msg: logMessage, GameID: gdb.Current.ID}
-			if err := db.GamesDB.Create(&entry).Error; err != nil {
+			entry, err := gdb.AddRoll(gdb.Current, roll)
+			if err != nil {
 				return fmt.Errorf("failed to save game after fate roll: %w", err)
 			}
+			out.EntryID = &entry.ID
+			out.CreatedAt = &entry.CreatedAt
 		}
 
+		if output.IsJSON() {
+			return output.Print(cmd.OutOrStdout(), out)
+		}
 		return nil
 	},
 }
@@ -107,6 +132,33 @@ func init() {
 	RollFateCmd.Flags().BoolVarP(&opposedRoll, "opposed", "o", false, "make the difficulty an opposed roll")
 }
 
+// fateOutput is the JSON form of a 4dF roll. Opponent holds the opposing roll of an
+// opposed roll, whose Skill is the opponent's base difficulty.
+type fateOutput struct {
+	Question   string      `json:"question,omitempty"`
+	Dice       []int       `json:"dice"`
+	DiceTotal  int         `json:"dice_total"`
+	Skill      *int        `json:"skill,omitempty"`
+	Total      int         `json:"total"`
+	Difficulty *int        `json:"difficulty,omitempty"`
+	Opponent   *fateOutput `json:"opponent,omitempty"`
+	Outcome    string      `json:"outcome,omitempty"`
+	Shifts     *int        `json:"shifts,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	EntryID    *uuid.UUID  `json:"entry_id,omitempty"`
+	CreatedAt  *time.Time  `json:"created_at,omitempty"`
+}
+
+// fateDice returns the four dice of a roll (-1, 0 or +1 each).
+// dice.Roll keeps them unexported, so they are read back from its String form.
+func fateDice(r *dice.Roll) []int {
+	d := make([]int, 4)
+	if _, err := fmt.Sscanf(r.String(), "{ %d, %d, %d, %d }", &d[0], &d[1], &d[2], &d[3]); err != nil {
+		return nil
+	}
+	return d
+}
+
 // getOutcomeString converts a numeric outcome (difference between roll and difficulty)
 // into a descriptive string for Fate dice rolls.
 // Outcomes:
@@ -5,17 +5,21 @@ package roll
 import (
 	"fmt"
 
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/DMXMax/mge/chart"
-	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mge/util"
+	"github.com/DMXMax/mythic-cli/util/config"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+	"github.com/DMXMax/mythic-cli/util/output"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
-// RollCmd rolls on the Mythic Fate Chart using the current game's chaos factor.
+// RollCmd rolls on the Mythic Fate Chart (or the game's chosen oracle) using the current game's chaos factor.
 // The chaos factor and odds can be overridden with flags.
 // An optional message can be provided which will be logged with the result.
 var RollCmd = &cobra.Command{
@@ -24,7 +28,9 @@ var RollCmd = &cobra.Command{
 	Long: `Roll on the Mythic chart using the game's chaos factor.
 A message for the roll is optional. If provided, it will be logged with the result.
 The chaos factor can be set with the -c flag (1-9).
-The odds can be set with the -o flag (default: 50/50). Use -o ? to list all available odds.`,
+The odds can be set with the -o flag (default: 50/50). Use -o ? to list all available odds.
+The question is answered by the game's oracle (see 'game oracle'), the Fate Chart by default.
+Use --oracle <name> to ask a different oracle for a single roll, e.g. --oracle fatecheck.`,
 	RunE: RollFunc,
 }
 
@@ -32,6 +38,10 @@ func RollFunc(cmd *cobra.Command, args []string) error {
 	var odds chart.Odds
 	messageArgs := args
 	g := gdb.Current
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
 
 	// Get chaos value from flag (user-facing: 1-9)
 	userChaos, err := cmd.Flags().GetInt8("chaos")
@@ -48,11 +58,15 @@ func RollFunc(cmd *cobra.Command, args []string) error {
 	// Determine internal chaos value (0-8)
 	var chaosValue int8
 	if !cmd.Flags().Changed("chaos") {
-		// Use game's chaos if not explicitly set, otherwise use default
+		// Use game's chaos if not explicitly set, otherwise the default_chaos setting (5 unless configured)
 		if g != nil {
 			chaosValue = g.Chaos
 		} else {
-			chaosValue = 4 // default internal chaos value (corresponds to user value 5)
+			defaultChaos, err := cfg.Int(config.DefaultChaos)
+			if err != nil {
+				return err
+			}
+			chaosValue = int8(chart.ChaosUserToInternal(defaultChaos))
 		}
 	} else {
 		// Validate user chaos input
@@ -63,90 +77,115 @@ func RollFunc(cmd *cobra.Command, args []string) error {
 		chaosValue = int8(chart.ChaosUserToInternal(int(userChaos)))
 	}
 
-	// Parse odds - default to 50/50 (FiftyFifty = 4) if not explicitly set
+	// Without -o, use the default_odds setting (50/50 unless configured)
 	if !cmd.Flags().Changed("odds") {
-		odds = chart.FiftyFifty // Default to 50/50
-	} else {
-		// Normalize input for odds string
-		normalized := normalizeOddsInput(oddsStr)
-
-		// Provide helper listing when -o ? is used
-		if normalized == "?" {
-			printOddsHelp()
-			return nil
-		}
-
-		// Try numeric odds first
-		parsed, err := strconv.ParseInt(normalized, 10, 8)
-		if err == nil {
-			if parsed < 0 || parsed > 8 {
-				return fmt.Errorf("odds must be between 0 and 8")
-			}
-			odds = chart.Odds(parsed)
-		} else { // not a number, try to match it to a string
-			matches := chart.MatchOddsPrefix(normalized)
-			if len(matches) == 0 {
-				err := fmt.Errorf("invalid odds: '%s'", oddsStr)
-				log.Error().Err(err).Msg("Invalid odds")
-				return err
-			}
-			if len(matches) != 1 { // multiple possible odds
-				fmt.Println("Did you mean one of these odds?")
-				for _, match := range matches {
-					fmt.Printf("%d : %s\n", match, chart.OddsStrList[match])
-				}
-				return fmt.Errorf("multiple possible odds for '%s'", oddsStr)
-			}
+		oddsStr = cfg.Value(config.DefaultOdds)
+	}
 
-			odds = chart.Odds(matches[0])
-		}
+	// Provide helper listing when -o ? is used
+	if oracle.NormalizeOdds(oddsStr) == "?" {
+		printOddsHelp()
+		return nil
+	}
+	odds, err = oracle.ParseOdds(oddsStr)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid odds")
+		return err
 	}
 
 	message := strings.Join(messageArgs, " ")
 	if len(message) > 256 {
 		return fmt.Errorf("message cannot be longer than 256 characters")
 	}
-	result := chart.FateChart.RollOdds(odds, int(chaosValue))
+	// Determine the oracle: the --oracle flag, then the game's setting, then the oracle setting
+	oracleName := cfg.Value(config.Oracle)
+	if cmd.Flags().Changed("oracle") {
+		if oracleName, err = cmd.Flags().GetString("oracle"); err != nil {
+			return fmt.Errorf("failed to get oracle flag: %w", err)
+		}
+	} else if g != nil {
+		if oracleName, err = gdb.GameOracle(g.ID); err != nil {
+			return err
+		}
+	}
+	o, err := oracle.Find(oracleName)
+	if err != nil {
+		return err
+	}
+
+	displayChaos := chart.ChaosInternalToUser(int(chaosValue))
+	result := o.Ask(odds, displayChaos)
 
-	// Display chaos in user-facing format (1-9)
-	logMessage := strings.TrimSpace(fmt.Sprintf("%s (C:%d) -> %s", message, chart.ChaosInternalToUser(int(chaosValue)), result))
+	// The log message is rendered from the structured roll, with chaos in user-facing format (1-9)
+	roll := gdb.NewOracleRoll(message, result)
+	logMessage := roll.String()
+
+	if !output.IsJSON() {
+		fmt.Println(logMessage)
+	}
+	out := rollOutput{Question: message, Result: result, Message: logMessage}
 
-	fmt.Println(logMessage)
+	var entry *gdb.LogEntry
 	if gdb.Current != nil {
 		// Create log entry directly in database to avoid duplicates
-		entry := gdb.LogEntry{Type: gdb.LogTypeDiceRoll, Msg: logMessage, GameID: gdb.Current.ID}
-		if err := db.GamesDB.Create(&entry).Error; err != nil {
+		if entry, err = gdb.AddRoll(gdb.Current, roll); err != nil {
 			return fmt.Errorf("failed to save log entry: %w", err)
 		}
+		out.EntryID = &entry.ID
+		out.CreatedAt = &entry.CreatedAt
+	}
+
+	// The oracle reports whether the roll triggers a Random Event
+	// (e.g. doubles at or below the chaos factor)
+	if result.RandomEvent {
+		event := &gdb.ResolvedEvent{Event: util.GetEvent()}
+		if g != nil {
+			// Resolve the event focus against the game's Threads and Characters Lists
+			if event, err = gdb.GetEvent(g.ID); err != nil {
+				return fmt.Errorf("failed to generate random event: %w", err)
+			}
+		}
+		if !output.IsJSON() {
+			fmt.Printf("Random Event: %s\n", event.String())
+		}
+		var eventEntry *gdb.LogEntry
+		if entry != nil {
+			if eventEntry, err = gdb.AddLinkedLog(gdb.Current, entry, gdb.LogTypeEvent, event.String()); err != nil {
+				return fmt.Errorf("failed to save random event: %w", err)
+			}
+		}
+		out.RandomEvent = output.NewEvent(event, eventEntry)
 	}
 
+	if output.IsJSON() {
+		return output.Print(cmd.OutOrStdout(), out)
+	}
 	return nil
 
 }
 
+// rollOutput is the JSON form of a roll.
+type rollOutput struct {
+	Question    string         `json:"question"`
+	Result      *oracle.Result `json:"result"`
+	Message     string         `json:"message"`
+	EntryID     *uuid.UUID     `json:"entry_id,omitempty"`
+	CreatedAt   *time.Time     `json:"created_at,omitempty"`
+	RandomEvent *output.Event  `json:"random_event,omitempty"`
+}
+
 func init() {
 	RollCmd.Flags().Int8P("chaos", "c", 5, "set the chaos factor for the game (1-9)")
 	RollCmd.Flags().StringP("odds", "o", "fifty", "set the odds for the roll (name or number, default: 50/50, use -o ? to list)")
+	RollCmd.Flags().String("oracle", "", "oracle for this roll (see 'game oracle', default: the game's oracle)")
 	RollCmd.AddCommand(RollFateCmd)
-}
 
-// normalizeOddsInput normalizes odds input by lowercasing, trimming, and standardizing variants.
-// It handles common separators (hyphens, underscores, em dashes) and converts them to spaces,
-// and recognizes common numeric forms like "50/50" and converts them to "fifty fifty".
-func normalizeOddsInput(s string) string {
-	s = strings.TrimSpace(strings.ToLower(s))
-	// normalize common separators to spaces
-	s = strings.ReplaceAll(s, "—", " ") // em dash
-	s = strings.ReplaceAll(s, "-", " ")
-	s = strings.ReplaceAll(s, "_", " ")
-	// collapse all whitespace sequences
-	s = strings.Join(strings.Fields(s), " ")
-	// common alias for fifty fifty
-	switch s {
-	case "50/50", "50-50", "50 50", "50\u00A050":
-		return "fifty fifty"
-	}
-	return s
+	RollCmd.RegisterFlagCompletionFunc("odds", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return chart.OddsStrList, cobra.ShellCompDirectiveNoFileComp
+	})
+	RollCmd.RegisterFlagCompletionFunc("oracle", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return oracle.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 // printOddsHelp prints all available odds names and their numeric indices.
@@ -14,15 +14,21 @@ import (
 	"strings"
 
 	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mythic-cli/cmd/character"
 	"github.com/DMXMax/mythic-cli/cmd/descriptor"
 	"github.com/DMXMax/mythic-cli/cmd/scene"
+	"github.com/DMXMax/mythic-cli/cmd/thread"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
 
 	"github.com/DMXMax/mythic-cli/cmd/game"
 	gamelog "github.com/DMXMax/mythic-cli/cmd/log"
 	"github.com/DMXMax/mythic-cli/cmd/roll"
 
+	"github.com/DMXMax/mythic-cli/util/config"
+	"github.com/DMXMax/mythic-cli/util/db"
 	"github.com/DMXMax/mythic-cli/util/input"
+	"github.com/DMXMax/mythic-cli/util/output"
+	"github.com/DMXMax/mythic-cli/util/shortcut"
 	"github.com/peterh/liner"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,18 +43,48 @@ var rootCmd = &cobra.Command{
 
 Features:
 - Interactive shell for game management
+- Every shell command also runs directly, e.g. mythic-cli roll -o likely "Is it locked?"
 - Dice rolling with Mythic fate chart
 - Game state persistence with SQLite
 - Story logging and chaos factor management
 - Character and scene management
 
 Perfect for solo RPG adventures, GM-less gaming, and story generation.`,
+	// Every command runs against the database chosen by --db, MYTHIC_DB or the config file,
+	// and the current game: the one named by --game, otherwise the one selected by the
+	// last 'game load' or 'game create'
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.Set(outputFlag); err != nil {
+			return err
+		}
+		if err := openDatabase(); err != nil {
+			if worksWithoutDatabase(cmd) {
+				return nil
+			}
+			return err
+		}
+		if gameFlag != "" {
+			g, err := gdb.FindGame(gameFlag)
+			if err != nil {
+				return err
+			}
+			gdb.Current = g
+			return nil
+		}
+		return gdb.RestoreCurrent()
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Usage()
 		return nil
 	},
 }
 
+// gameFlag holds the --game flag: the game to use for this invocation only.
+var gameFlag string
+
+// outputFlag holds the --output flag: text or json.
+var outputFlag string
+
 // errQuit is a sentinel error to signal a clean exit from the shell.
 // When returned from a command, it causes the shell loop to exit gracefully.
 var errQuit = errors.New("user requested quit")
@@ -63,21 +99,29 @@ var shellCmd = &cobra.Command{
 with persistent history and line editing support.
 
 The shell prompt displays the current game name and chaos factor (C)
-when a game is loaded. Use 'quit' or press Ctrl-C/Ctrl-D to exit.
+when a game is loaded (see the prompt setting). Use 'quit' or press Ctrl-C/Ctrl-D to exit.
 
-Command history is persisted to ~/.mythic-cli_history and can be navigated
-using the Up/Down arrow keys.`,
+Command history is persisted to ~/.mythic-cli_history (see the history_file
+setting) and can be navigated using the Up/Down arrow keys. The commands in ~/.mythic-clirc, if it exists,
+are run when the shell starts.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Use liner to get arrow-key history and line editing
 		l := liner.NewLiner()
 		defer l.Close()
 		l.SetCtrlCAborts(true)
+		l.SetWordCompleter(shellCompleter(cmd))
 		// Make liner available to commands for sub-prompts
 		input.SetPrompter(l)
 
-		// Load/save persistent history
-		home, _ := os.UserHomeDir()
-		histPath := filepath.Join(home, ".mythic-cli_history")
+		// Load/save persistent history, kept in the history_file setting
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		histPath, err := cfg.File(config.HistoryFile)
+		if err != nil {
+			return err
+		}
 		if f, err := os.Open(histPath); err == nil {
 			_, _ = l.ReadHistory(f)
 			_ = f.Close()
@@ -89,12 +133,13 @@ using the Up/Down arrow keys.`,
 			}
 		}()
 
+		// Run the user's startup commands
+		runRCFile(cmd)
+
 		for {
-			var prompt string
-			if gdb.Current != nil {
-				g := gdb.Current
-				prompt = fmt.Sprintf("%s (C:%d)> ", g.Name, chart.ChaosInternalToUser(int(g.Chaos)))
-			} else {
+			prompt, err := shellPrompt()
+			if err != nil {
+				cmd.Println(err)
 				prompt = "shell> "
 			}
 
@@ -111,68 +156,170 @@ using the Up/Down arrow keys.`,
 				return err
 			}
 
-			input := strings.TrimSpace(line)
-			if input == "" {
-				continue
-			}
-
-			fields := strings.Fields(input)
-			newCmd, newArgs, err := cmd.Find(fields)
-			if err != nil {
-				cmd.Println(err)
-				continue
-			}
-			// If Find returns the same command, it means no subcommand was found.
-			if newCmd == cmd {
-				cmd.Printf("Error: unknown command \"%s\" for \"%s\"\n", fields[0], cmd.CommandPath())
+			text := strings.TrimSpace(line)
+			if text == "" {
 				continue
 			}
 
 			// Append to history before execution
-			l.AppendHistory(input)
-
-			// Check if help is requested
-			hasHelp := false
-			for _, arg := range newArgs {
-				if arg == "--help" || arg == "-h" {
-					hasHelp = true
-					break
+			l.AppendHistory(text)
+
+			if err := runShellLine(cmd, text); err != nil {
+				if errors.Is(err, errQuit) {
+					return nil // Gracefully exit the shell loop
 				}
+				cmd.Println(err) // Print other errors
 			}
+		}
+	},
+}
 
-			if hasHelp {
-				// Show help for the command
-				newCmd.Help()
-			} else {
-				// Set the args for the command and execute it normally
-				newCmd.SetArgs(newArgs)
-
-				// Parse flags to ensure default values are set
-				if err := newCmd.Flags().Parse(newArgs); err != nil {
-					cmd.Println(err)
-					continue
-				}
+// shellPrompt returns the shell prompt: the prompt setting with the current game
+// filled in, or "shell> " when no game is loaded.
+func shellPrompt() (string, error) {
+	g := gdb.Current
+	if g == nil {
+		return "shell> ", nil
+	}
+	// Read the setting each time so 'config set prompt' takes effect immediately
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	r := strings.NewReplacer(
+		"{game}", g.Name,
+		"{chaos}", fmt.Sprint(chart.ChaosInternalToUser(int(g.Chaos))),
+		"{db}", strings.TrimSuffix(filepath.Base(db.Path), ".db"),
+	)
+	return r.Replace(cfg.Value(config.Prompt)), nil
+}
 
-				if newCmd.RunE != nil {
-					// After parsing, the non-flag arguments are available via Flags().Args()
-					if err := newCmd.RunE(newCmd, newCmd.Flags().Args()); err != nil {
-						if errors.Is(err, errQuit) {
-							return nil // Gracefully exit the shell loop
-						}
-						cmd.Println(err) // Print other errors
-					}
-				} else if newCmd.Run != nil {
-					newCmd.Run(newCmd, newCmd.Flags().Args())
-				}
+// runShellLine splits a line of shell input, expands aliases and macros, and runs
+// the resulting commands in order. It stops at the first command that fails and
+// returns its error, which is errQuit if the command asked to leave the shell.
+func runShellLine(shell *cobra.Command, text string) error {
+	fields, err := input.SplitArgs(text)
+	if err != nil {
+		return err
+	}
+	shortcuts, err := shortcut.Load()
+	if err != nil {
+		return err
+	}
+	commands, err := shortcuts.Expand(fields)
+	if err != nil {
+		return err
+	}
+	for _, fields := range commands {
+		if err := runShellCommand(shell, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-				// Reset flags on the executed command to avoid carry-over in the shell
-				newCmd.Flags().VisitAll(func(f *pflag.Flag) {
-					f.Value.Set(f.DefValue)
-					f.Changed = false
-				})
+// runShellCommand finds the command named by fields below the shell command and runs it.
+func runShellCommand(shell *cobra.Command, fields []string) error {
+	newCmd, newArgs, err := shell.Find(fields)
+	if err != nil {
+		return err
+	}
+	// If Find returns the same command, it means no subcommand was found.
+	if newCmd == shell {
+		return fmt.Errorf("unknown command \"%s\" for \"%s\"", fields[0], shell.CommandPath())
+	}
+
+	// Commands that parse their own flags get the arguments untouched
+	if newCmd.DisableFlagParsing {
+		return newCmd.RunE(newCmd, newArgs)
+	}
+
+	// Check if help is requested
+	for _, arg := range newArgs {
+		if arg == "--help" || arg == "-h" {
+			// Show help for the command
+			return newCmd.Help()
+		}
+	}
+
+	// Reset flags on the executed command to avoid carry-over in the shell
+	defer newCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+
+	// Set the args for the command and execute it normally
+	newCmd.SetArgs(newArgs)
+
+	// Parse flags to ensure default values are set
+	if err := newCmd.Flags().Parse(newArgs); err != nil {
+		return err
+	}
+
+	restore, err := applyCommandFlags(newCmd)
+	if err != nil {
+		return err
+	}
+
+	// After parsing, the non-flag arguments are available via Flags().Args()
+	if newCmd.RunE != nil {
+		err = newCmd.RunE(newCmd, newCmd.Flags().Args())
+	} else if newCmd.Run != nil {
+		newCmd.Run(newCmd, newCmd.Flags().Args())
+	}
+	return errors.Join(err, restore())
+}
+
+// applyCommandFlags applies the --game, --db and --output flags given to a single shell
+// command, which PersistentPreRunE only handles for the shell itself. The returned
+// function switches back to the database, game and output format in use before the command.
+func applyCommandFlags(cmd *cobra.Command) (restore func() error, err error) {
+	if !cmd.Flags().Changed("db") && gameFlag == "" && !cmd.Flags().Changed("output") {
+		return func() error { return nil }, nil
+	}
+	prevPath, prevGame, prevFormat := db.Path, gdb.Current, output.Format()
+	restore = func() error {
+		if err := output.Set(prevFormat); err != nil {
+			return err
+		}
+		if db.Path != prevPath {
+			if err := gdb.OpenDatabase(prevPath); err != nil {
+				return err
 			}
 		}
-	},
+		gdb.Current = nil
+		if prevGame != nil {
+			// Reload the game, which the command may have changed
+			g, err := gdb.LoadGame(prevGame.ID)
+			if err != nil {
+				return fmt.Errorf("failed to restore current game: %w", err)
+			}
+			gdb.Current = g
+		}
+		return nil
+	}
+
+	if cmd.Flags().Changed("output") {
+		if err := output.Set(outputFlag); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Flags().Changed("db") {
+		if err := openDatabase(); err != nil {
+			return nil, err
+		}
+		if err := gdb.RestoreCurrent(); err != nil {
+			return nil, errors.Join(err, restore())
+		}
+	}
+	if gameFlag != "" {
+		g, err := gdb.FindGame(gameFlag)
+		if err != nil {
+			return nil, errors.Join(err, restore())
+		}
+		gdb.Current = g
+	}
+	return restore, nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -197,17 +344,52 @@ var shellQuitCmd = &cobra.Command{
 }
 
 func init() {
+	// Game commands are available both in the interactive shell and as one-shot
+	// invocations such as "mythic-cli roll -o likely Is it locked?"
+	commands := []*cobra.Command{scene.SceneCmd, game.GameCmd,
+		roll.RollCmd, roll.RollFateCmd, gamelog.LogCmd, descriptor.DescriptorCmd, thread.ThreadCmd,
+		character.CharacterCmd, dbCmd, configCmd}
+
 	// Register all subcommands for the interactive shell
-	shellCmd.AddCommand(shellQuitCmd, scene.SceneCmd, game.GameCmd,
-		roll.RollCmd, roll.RollFateCmd, gamelog.LogCmd, descriptor.DescriptorCmd, shellHelpCommand)
+	shellCmd.AddCommand(shellQuitCmd, shellHelpCommand, aliasCmd, macroCmd, sourceCmd, shellOutputCmd)
+	shellCmd.AddCommand(commands...)
+
+	// Add the shell and the game commands to the root command
+	rootCmd.AddCommand(shellCmd, runCmd)
+	rootCmd.AddCommand(commands...)
 
-	// Add the shell command to the root command
-	rootCmd.AddCommand(shellCmd)
+	// Write command output to stdout so one-shot invocations can be piped,
+	// and don't repeat the usage text after every error
+	rootCmd.SetOut(os.Stdout)
+	rootCmd.SilenceUsage = true
+
+	rootCmd.PersistentFlags().StringVarP(&gameFlag, "game", "g", "", "use this game instead of the current one (not remembered)")
+	rootCmd.PersistentFlags().StringVar(&dbFlag, "db", "", "database name or file to use instead of the configured one (not remembered)")
+	rootCmd.PersistentFlags().StringVar(&outputFlag, "output", output.Text, "output format: text or json")
 
 	// Root command flags (currently unused, but available for future use)
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
+// shellOutputCmd switches the output format of the interactive shell.
+var shellOutputCmd = &cobra.Command{
+	Use:   "output [text|json]",
+	Short: "Set or show the output format",
+	Long: `Set or show the output format of the shell. In json mode, roll, rollfate, gamelog print,
+game info, game list, scene status and descriptor print JSON documents instead of text.
+To switch it for a single command, give that command the --output flag.`,
+	ValidArgs: []string{output.Text, output.JSON},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			if err := output.Set(args[0]); err != nil {
+				return err
+			}
+		}
+		cmd.Printf("Output: %s\n", output.Format())
+		return nil
+	},
+}
+
 // shellHelpCommand provides help functionality within the interactive shell.
 // It allows users to get help for any command by typing "help <command>".
 var shellHelpCommand = &cobra.Command{
@@ -2,58 +2,259 @@ package scene
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/DMXMax/mge/storage"
-	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mge/chart"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/input"
 	"github.com/spf13/cobra"
 )
 
-// endCmd ends the current active scene.
-// This deactivates the scene but does not handle Threads/Characters Lists updates
-// (those should be done via the scene_end command/tool separately).
+// endCmd ends the current active scene and walks through the Mythic end-of-scene
+// bookkeeping: adjusting the Chaos Factor, updating the Threads and Characters Lists,
+// and capturing a summary of the scene.
 var endCmd = &cobra.Command{
 	Use:   "end",
 	Short: "End the current scene",
-	Long: `Ends the current active scene. This deactivates the scene in the database.
-Note: To update Threads/Characters Lists and adjust Chaos Factor, use the scene_end command separately.`,
+	Long: `Ends the current active scene and walks through the Mythic end-of-scene procedure:
+
+1. Were the PCs in control of the scene? If yes, the Chaos Factor goes down by 1,
+   otherwise it goes up by 1 (within 1-9).
+2. Add new threads and remove (resolve) finished ones.
+3. Add new characters and remove (retire) characters who are no longer relevant.
+4. Write a short summary of the scene.
+
+Everything is recorded in a single scene end entry in the game log.
+Use --control and --summary to answer those questions up front,
+and --no-prompt to skip all remaining questions.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		g := gdb.Current
 		if g == nil {
 			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
 		}
 
-		// Find and deactivate active scene
-		result := db.GamesDB.Model(&storage.Scene{}).
-			Where("game_id = ? AND is_active = ?", g.ID, true).
-			Update("is_active", false)
-
-		if result.Error != nil {
-			return fmt.Errorf("failed to end scene: %w", result.Error)
+		noPrompt, err := cmd.Flags().GetBool("no-prompt")
+		if err != nil {
+			return fmt.Errorf("failed to get no-prompt flag: %w", err)
+		}
+		controlStr, err := cmd.Flags().GetString("control")
+		if err != nil {
+			return fmt.Errorf("failed to get control flag: %w", err)
+		}
+		summary, err := cmd.Flags().GetString("summary")
+		if err != nil {
+			return fmt.Errorf("failed to get summary flag: %w", err)
 		}
 
-		if result.RowsAffected == 0 {
+		// Find the active scene
+		current, err := gdb.ActiveScene(g.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
 			cmd.Println("No active scene to end.")
 			return nil
 		}
 
-		cmd.Println("Scene ended.")
+		// Collect every answer before saving anything, so an interrupted prompt
+		// leaves the chaos factor, the lists and the scene as they were
 
-		// Log scene end
-		entry := gdb.LogEntry{
-			Type:  0,
-			Msg:   "--- Scene End ---",
-			GameID: g.ID,
+		// 1. Chaos Factor adjustment
+		var inControl *bool
+		if cmd.Flags().Changed("control") {
+			v, ok := parseYesNo(controlStr)
+			if !ok {
+				return fmt.Errorf("invalid control value: %q (use yes or no)", controlStr)
+			}
+			inControl = &v
+		} else if !noPrompt {
+			v, err := askYesNo("Were the PCs in control of this scene? [y/n]: ")
+			if err != nil {
+				return err
+			}
+			inControl = &v
 		}
-		if err := db.GamesDB.Create(&entry).Error; err != nil {
-			return fmt.Errorf("failed to log scene end: %w", err)
+
+		end := gdb.SceneEnd{Chaos: g.Chaos, PCInControl: inControl}
+		parts := []string{current.ExpectedConcept}
+		var report []string
+		if inControl != nil {
+			before := chart.ChaosInternalToUser(int(g.Chaos))
+			after := min(before+1, chart.MaxChaosUser)
+			control := "no"
+			if *inControl {
+				after = max(before-1, chart.MinChaosUser)
+				control = "yes"
+			}
+			end.Chaos = int8(chart.ChaosUserToInternal(after))
+			chaosMsg := fmt.Sprintf("PCs in control: %s, Chaos %d -> %d", control, before, after)
+			parts = append(parts, chaosMsg)
+			report = append(report, chaosMsg)
+		}
+
+		// 2. and 3. Threads and Characters Lists
+		if !noPrompt {
+			changes, err := askLists(g, &end)
+			if err != nil {
+				return err
+			}
+			parts = append(parts, changes...)
+			report = append(report, changes...)
 		}
 
+		// 4. Scene summary
+		if !cmd.Flags().Changed("summary") && !noPrompt {
+			summary, err = input.Ask("Scene summary (blank to skip): ")
+			if err != nil {
+				return fmt.Errorf("failed to read summary: %w", err)
+			}
+		}
+		end.Summary = strings.TrimSpace(summary)
+		if end.Summary != "" {
+			parts = append(parts, "Summary: "+end.Summary)
+		}
+
+		// Apply the bookkeeping, deactivate the scene and log the scene end with
+		// all bookkeeping in one entry
+		end.Msg = strings.Join(parts, " | ")
+		if err := gdb.EndScene(g, current, end); err != nil {
+			return err
+		}
+		for _, r := range report {
+			cmd.Println(r)
+		}
+		cmd.Println("Scene ended.")
 		return nil
 	},
 }
 
 func init() {
+	endCmd.Flags().String("control", "", "whether the PCs were in control of the scene (yes/no)")
+	endCmd.Flags().String("summary", "", "summary of the scene")
+	endCmd.Flags().BoolP("no-prompt", "n", false, "do not ask for anything that was not given by flags")
 	SceneCmd.AddCommand(endCmd)
 }
 
+// askLists prompts for additions to and removals from the Threads and Characters
+// Lists and records them in end. Removed threads are resolved and removed characters
+// retired, so both remain in the game's history. It returns a description of each change.
+func askLists(g *gdb.Game, end *gdb.SceneEnd) ([]string, error) {
+	var changes []string
+
+	added, err := askNames("New thread (blank to finish): ", func(name string) (string, error) {
+		end.NewThreads = append(end.NewThreads, name)
+		return name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	removed, err := askNames("Thread to remove (number or name, blank to finish): ", func(ref string) (string, error) {
+		t, err := gdb.FindThread(g.ID, ref)
+		if err != nil {
+			return "", err
+		}
+		if t.Status == gdb.ThreadResolved || slices.ContainsFunc(end.ResolvedThreads, func(r gdb.Thread) bool { return r.ID == t.ID }) {
+			return "", fmt.Errorf("thread '%s' is already %s", t.Name, gdb.ThreadResolved)
+		}
+		end.ResolvedThreads = append(end.ResolvedThreads, *t)
+		return t.Name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c := describeChanges("Threads", added, removed); c != "" {
+		changes = append(changes, c)
+	}
+
+	added, err = askNames("New character (blank to finish): ", func(name string) (string, error) {
+		end.NewCharacters = append(end.NewCharacters, name)
+		return name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	removed, err = askNames("Character to remove (number or name, blank to finish): ", func(ref string) (string, error) {
+		c, err := gdb.FindCharacter(g.ID, ref)
+		if err != nil {
+			return "", err
+		}
+		if c.Status == gdb.CharacterInactive || slices.ContainsFunc(end.RetiredCharacters, func(r gdb.Character) bool { return r.ID == c.ID }) {
+			return "", fmt.Errorf("character '%s' is already %s", c.Name, gdb.CharacterInactive)
+		}
+		end.RetiredCharacters = append(end.RetiredCharacters, *c)
+		return c.Name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c := describeChanges("Characters", added, removed); c != "" {
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// askNames repeatedly prompts until a blank answer, calling apply for each answer.
+// Errors from apply are reported and the question is asked again.
+// It returns the names returned by apply.
+func askNames(prompt string, apply func(string) (string, error)) ([]string, error) {
+	var names []string
+	for {
+		ans, err := input.Ask(prompt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answer: %w", err)
+		}
+		ans = strings.TrimSpace(ans)
+		if ans == "" {
+			return names, nil
+		}
+		name, err := apply(ans)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		names = append(names, name)
+	}
+}
+
+// describeChanges formats list changes as "Label: +added, -removed".
+// It returns an empty string if nothing changed.
+func describeChanges(label string, added, removed []string) string {
+	var items []string
+	for _, n := range added {
+		items = append(items, "+"+n)
+	}
+	for _, n := range removed {
+		items = append(items, "-"+n)
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", label, strings.Join(items, ", "))
+}
+
+// askYesNo prompts until the user answers yes or no.
+func askYesNo(prompt string) (bool, error) {
+	for {
+		ans, err := input.Ask(prompt)
+		if err != nil {
+			return false, fmt.Errorf("failed to read answer: %w", err)
+		}
+		if v, ok := parseYesNo(ans); ok {
+			return v, nil
+		}
+	}
+}
+
+// parseYesNo interprets a yes/no answer. The second return value is false
+// if the answer could not be understood.
+func parseYesNo(s string) (bool, bool) {
+	switch strings.TrimSpace(strings.ToLower(s)) {
+	case "y", "yes", "true":
+		return true, true
+	case "n", "no", "false":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,68 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// listCmd lists every scene of the current game.
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l", "ls"},
+	Short:   "List all scenes of the current game",
+	Long:    `Lists every scene of the current game with its number, type, Chaos Die roll, expected concept and summary.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		scenes, err := gdb.GetScenes(g.ID)
+		if err != nil {
+			return err
+		}
+		if len(scenes) == 0 {
+			cmd.Println("No scenes yet. Use 'scene start <description>' to start one.")
+			return nil
+		}
+
+		cmd.Println("Scenes:")
+		for _, s := range scenes {
+			line := fmt.Sprintf("  %d. [%s] %s (Chaos Die: %d)", s.Detail.Number, strings.Title(s.Type), s.ExpectedConcept, s.ChaosDieRoll)
+			if s.IsActive {
+				line += " *active*"
+			}
+			cmd.Println(line)
+			if s.Detail.Summary != "" {
+				cmd.Printf("     %s\n", s.Detail.Summary)
+			}
+		}
+		return nil
+	},
+}
+
+// renumberCmd renumbers the scenes of the current game sequentially.
+var renumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Renumber all scenes of the current game",
+	Long:  `Renumber all scenes of the current game 1..n in the order they were started, closing any gaps.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+		if err := gdb.NumberScenes(g.ID, true); err != nil {
+			return err
+		}
+		cmd.Println("Scenes renumbered.")
+		return nil
+	},
+}
+
+func init() {
+	SceneCmd.AddCommand(listCmd)
+	SceneCmd.AddCommand(renumberCmd)
+}
@@ -2,6 +2,9 @@
 package scene
 
 import (
+	"strconv"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
 	"github.com/spf13/cobra"
 )
 
@@ -12,5 +15,24 @@ var SceneCmd = &cobra.Command{
 	Long: `Manage scenes in your Mythic game. Scenes represent distinct moments or locations in your game narrative.
 
 When you start a scene, the Chaos Die is automatically rolled to determine if the scene proceeds as expected,
-is altered, or is interrupted. Altered and Interrupted scenes generate Random Events.`,
+is altered, or is interrupted. Altered scenes roll on the Scene Adjustment Table and
+Interrupted scenes generate Random Events.
+
+Every scene is numbered; use 'scene list' and 'scene show <n>' to review past scenes.`,
+}
+
+// completeScenes completes a scene number argument with the numbers of the current game's scenes.
+func completeScenes(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 || gdb.Current == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	scenes, err := gdb.GetScenes(gdb.Current.ID)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	numbers := make([]cobra.Completion, 0, len(scenes))
+	for _, s := range scenes {
+		numbers = append(numbers, cobra.CompletionWithDesc(strconv.Itoa(s.Detail.Number), s.ExpectedConcept))
+	}
+	return numbers, cobra.ShellCompDirectiveNoFileComp
 }
@@ -0,0 +1,82 @@
+package scene
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// showCmd displays a single scene with all its details and log entries.
+var showCmd = &cobra.Command{
+	Use:   "show <n>",
+	Short: "Show a scene and the log entries recorded in it",
+	Long: `Shows scene number n of the current game: its type, Chaos Die roll, expected concept,
+end-of-scene details and summary, followed by every log entry recorded during the scene.
+Use 'scene list' to see the scene numbers.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeScenes,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("show requires a scene number")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid scene number: %s", args[0])
+		}
+
+		s, err := gdb.FindScene(g.ID, n)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Scene %d: %s\n", s.Detail.Number, s.ExpectedConcept)
+		cmd.Printf("  Type: %s\n", strings.Title(s.Type))
+		cmd.Printf("  Chaos Die Roll: %d\n", s.ChaosDieRoll)
+		if s.Detail.Adjustment != "" {
+			cmd.Printf("  Scene Adjustment: %s\n", s.Detail.Adjustment)
+		}
+		if s.Detail.Event != "" {
+			cmd.Printf("  Random Event: %s\n", s.Detail.Event)
+		}
+		cmd.Printf("  Started: %s\n", s.CreatedAt.Format("2006-01-02 15:04:05"))
+		if s.IsActive {
+			cmd.Println("  Status: active")
+		} else {
+			cmd.Println("  Status: ended")
+		}
+		if s.Detail.PCInControl != nil {
+			if *s.Detail.PCInControl {
+				cmd.Println("  PCs in control: yes")
+			} else {
+				cmd.Println("  PCs in control: no")
+			}
+		}
+		if s.Detail.Summary != "" {
+			cmd.Printf("  Summary: %s\n", s.Detail.Summary)
+		}
+
+		entries, err := gdb.GetSceneEntries(s.ID)
+		if err != nil {
+			return err
+		}
+		cmd.Println("\nLog:")
+		if len(entries) == 0 {
+			cmd.Println("  No log entries found.")
+		}
+		for _, e := range entries {
+			cmd.Printf("  %s - %s\n", e.CreatedAt.Format("15:04:05"), e.Msg)
+		}
+		return nil
+	},
+}
+
+func init() {
+	SceneCmd.AddCommand(showCmd)
+}
@@ -18,7 +18,11 @@ var startCmd = &cobra.Command{
 	Use:   "start <description>",
 	Short: "Start a new scene",
 	Long: `Start a new scene with an Expected Scene concept. The Chaos Die is automatically rolled
-to determine if the scene proceeds as expected, is altered, or is interrupted.`,
+to determine if the scene proceeds as expected, is altered, or is interrupted.
+
+Altered scenes roll on the Scene Adjustment Table (remove/add a character, reduce/increase an
+activity, remove/add an object, or two adjustments) and suggest a descriptor pair.
+Interrupted scenes generate a Random Event.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		g := gdb.Current
@@ -53,37 +57,48 @@ to determine if the scene proceeds as expected, is altered, or is interrupted.`,
 			return fmt.Errorf("failed to create scene: %w", err)
 		}
 
+		// Give the new scene its sequence number
+		if err := gdb.NumberScenes(g.ID, false); err != nil {
+			return err
+		}
+
 		// Display scene type and roll result
 		cmd.Printf("Scene Started: %s\n", rollResult.Description)
 		cmd.Printf("Expected Scene: %s\n", concept)
 
-		// If Altered or Interrupted, generate Random Event
-		if rollResult.SceneType == "altered" || rollResult.SceneType == "interrupt" {
-			event := util.GetEvent()
-			cmd.Printf("\nRandom Event: %s\n", event.String())
+		var eventMsg string
+		switch rollResult.SceneType {
+		case "altered":
+			// Altered scenes roll on the Scene Adjustment Table, with a descriptor pair for inspiration
+			adjustment := fmt.Sprintf("%s (%s)", strings.Join(scene.GetSceneAdjustment(), ", "),
+				strings.Join(util.GetMeaningDescriptors(), " "))
+			cmd.Printf("\nScene Adjustment: %s\n", adjustment)
 
-			// Log the event
-			eventMsg := fmt.Sprintf("--- Scene Start: %s | Expected: %s | Event: %s ---",
-				strings.Title(rollResult.SceneType), concept, event.String())
-			entry := gdb.LogEntry{
-				Type:  0,
-				Msg:   eventMsg,
-				GameID: g.ID,
-			}
-			if err := db.GamesDB.Create(&entry).Error; err != nil {
-				return fmt.Errorf("failed to log event: %w", err)
+			if err := db.GamesDB.Model(&gdb.SceneDetail{}).Where("scene_id = ?", newScene.ID).
+				Update("adjustment", adjustment).Error; err != nil {
+				return fmt.Errorf("failed to save scene adjustment: %w", err)
 			}
-		} else {
-			// Log expected scene start
-			eventMsg := fmt.Sprintf("--- Scene Start: Expected | %s ---", concept)
-			entry := gdb.LogEntry{
-				Type:  0,
-				Msg:   eventMsg,
-				GameID: g.ID,
+			eventMsg = fmt.Sprintf("Altered | %s | Adjustment: %s", concept, adjustment)
+		case "interrupt":
+			// Interrupted scenes are replaced by a Random Event
+			event, err := gdb.GetEvent(g.ID)
+			if err != nil {
+				return fmt.Errorf("failed to generate random event: %w", err)
 			}
-			if err := db.GamesDB.Create(&entry).Error; err != nil {
-				return fmt.Errorf("failed to log scene start: %w", err)
+			cmd.Printf("\nRandom Event: %s\n", event.String())
+
+			if err := db.GamesDB.Model(&gdb.SceneDetail{}).Where("scene_id = ?", newScene.ID).
+				Update("event", event.String()).Error; err != nil {
+				return fmt.Errorf("failed to save scene event: %w", err)
 			}
+			eventMsg = fmt.Sprintf("Interrupt | %s | Event: %s", concept, event.String())
+		default:
+			eventMsg = fmt.Sprintf("Expected | %s", concept)
+		}
+
+		// Log the scene start
+		if _, err := gdb.AddLog(g, gdb.LogTypeSceneStart, eventMsg); err != nil {
+			return fmt.Errorf("failed to log scene start: %w", err)
 		}
 
 		return nil
@@ -8,6 +8,7 @@ import (
 	"github.com/DMXMax/mge/storage"
 	"github.com/DMXMax/mythic-cli/util/db"
 	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/DMXMax/mythic-cli/util/output"
 	"github.com/spf13/cobra"
 )
 
@@ -29,19 +30,46 @@ var statusCmd = &cobra.Command{
 			First(&currentScene)
 
 		if result.Error != nil {
+			if output.IsJSON() {
+				return output.Print(cmd.OutOrStdout(), statusOutput{Chaos: chart.ChaosInternalToUser(int(g.Chaos))})
+			}
 			cmd.Println("No active scene.")
 			return nil
 		}
 
+		var detail gdb.SceneDetail
+		if err := db.GamesDB.Where("scene_id = ?", currentScene.ID).Limit(1).Find(&detail).Error; err != nil {
+			return fmt.Errorf("failed to load scene details: %w", err)
+		}
+
+		if output.IsJSON() {
+			return output.Print(cmd.OutOrStdout(), statusOutput{
+				Chaos: chart.ChaosInternalToUser(int(g.Chaos)),
+				Scene: output.NewScene(gdb.SceneRecord{Scene: currentScene, Detail: detail}),
+			})
+		}
+
 		// Display scene information
 		cmd.Printf("Current Scene:\n")
 		cmd.Printf("  Type: %s\n", strings.Title(currentScene.Type))
 		cmd.Printf("  Expected Concept: %s\n", currentScene.ExpectedConcept)
 		cmd.Printf("  Chaos Die Roll: %d (Chaos: %d)\n", currentScene.ChaosDieRoll, chart.ChaosInternalToUser(int(g.Chaos)))
+		if detail.Adjustment != "" {
+			cmd.Printf("  Scene Adjustment: %s\n", detail.Adjustment)
+		}
+		if detail.Event != "" {
+			cmd.Printf("  Random Event: %s\n", detail.Event)
+		}
 		return nil
 	},
 }
 
+// statusOutput is the JSON form of 'scene status'. Scene is null if no scene is active.
+type statusOutput struct {
+	Chaos int           `json:"chaos"`
+	Scene *output.Scene `json:"scene"`
+}
+
 func init() {
 	SceneCmd.AddCommand(statusCmd)
 }
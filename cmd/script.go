@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// maxScriptDepth limits how deeply scripts may source other scripts.
+const maxScriptDepth = 10
+
+// scriptDepth is the number of scripts currently being executed.
+var scriptDepth int
+
+// runCmd executes a script of shell commands from the command line.
+var runCmd = &cobra.Command{
+	Use:   "run <file>",
+	Short: "Run a file of shell commands",
+	Long: `Run each line of a file as if it had been typed in the interactive shell,
+including aliases and macros.
+
+Blank lines and lines starting with # are ignored. Execution stops at the first
+failing command unless --continue is given; 'quit' ends the script.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScriptCmd(cmd, args)
+	},
+}
+
+// sourceCmd executes a script of shell commands inside the interactive shell.
+var sourceCmd = &cobra.Command{
+	Use:   "source <file>",
+	Short: "Run a file of shell commands",
+	Long: `Run each line of a file as if it had been typed at the prompt,
+including aliases and macros.
+
+Blank lines and lines starting with # are ignored. Execution stops at the first
+failing command unless --continue is given; 'quit' ends the script, not the shell.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScriptCmd(cmd, args)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{runCmd, sourceCmd} {
+		c.Flags().Bool("continue", false, "keep going after a command fails")
+		c.Flags().BoolP("echo", "e", false, "print each command before running it")
+	}
+}
+
+// runScriptCmd is the shared implementation of runCmd and sourceCmd.
+func runScriptCmd(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("a script file is required")
+	}
+	keepGoing, err := cmd.Flags().GetBool("continue")
+	if err != nil {
+		return fmt.Errorf("failed to get continue flag: %w", err)
+	}
+	echo, err := cmd.Flags().GetBool("echo")
+	if err != nil {
+		return fmt.Errorf("failed to get echo flag: %w", err)
+	}
+	return runScript(cmd, shellCmd, args[0], keepGoing, echo)
+}
+
+// runScript runs each line of the file at path through the dispatch path of the shell command.
+// Blank lines and # comments are skipped. Unless keepGoing is set, it stops at the
+// first failing command and returns its error prefixed with the file and line number;
+// otherwise failures are printed and counted. A quit command ends the script.
+func runScript(cmd, shell *cobra.Command, path string, keepGoing, echo bool) error {
+	if scriptDepth >= maxScriptDepth {
+		return fmt.Errorf("%s: scripts nested too deeply", path)
+	}
+	scriptDepth++
+	defer func() { scriptDepth-- }()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open script: %w", err)
+	}
+	defer f.Close()
+
+	failed := 0
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if echo {
+			cmd.Printf("> %s\n", text)
+		}
+
+		err := runShellLine(shell, text)
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		if err != nil {
+			err = fmt.Errorf("%s:%d: %w", filepath.Base(path), n, err)
+			if !keepGoing {
+				return err
+			}
+			cmd.Println(err)
+			failed++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read script: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s: %d command(s) failed", filepath.Base(path), failed)
+	}
+	return nil
+}
+
+// runRCFile runs ~/.mythic-clirc in the shell, if it exists, printing any error.
+func runRCFile(shell *cobra.Command) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	path := filepath.Join(home, ".mythic-clirc")
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	if err := runScript(shell, shell, path, false, false); err != nil {
+		shell.Println(err)
+	}
+}
@@ -0,0 +1,61 @@
+package thread
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// addCmd adds a new thread to the current game's Threads List.
+var addCmd = &cobra.Command{
+	Use:     "add <name>",
+	Aliases: []string{"a", "new"},
+	Short:   "Add a thread to the Threads List",
+	Long: `Add a new thread to the Threads List of the current game.
+Use --description to attach a longer description and --weight to add it more than once (1-3).`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		name := strings.TrimSpace(strings.Join(args, " "))
+		if name == "" {
+			return fmt.Errorf("thread name cannot be empty")
+		}
+
+		weight, err := cmd.Flags().GetInt("weight")
+		if err != nil {
+			return fmt.Errorf("failed to get weight flag: %w", err)
+		}
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return fmt.Errorf("failed to get description flag: %w", err)
+		}
+
+		thread, err := gdb.AddThread(g.ID, name, strings.TrimSpace(description), weight)
+		if err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Thread added: %s", thread.Name)
+		if weight > 1 {
+			msg = fmt.Sprintf("%s (x%d)", msg, weight)
+		}
+		if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+			return fmt.Errorf("failed to log thread change: %w", err)
+		}
+
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	addCmd.Flags().StringP("description", "d", "", "optional description of the thread")
+	addCmd.Flags().IntP("weight", "w", 1, "number of times the thread appears on the list (1-3)")
+	ThreadCmd.AddCommand(addCmd)
+}
@@ -0,0 +1,69 @@
+package thread
+
+import (
+	"fmt"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// listCmd lists the threads of the current game.
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l", "ls"},
+	Short:   "List the Threads List",
+	Long: `List the threads of the current game with their list number and weight.
+Resolved threads are hidden unless --all is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("failed to get all flag: %w", err)
+		}
+		return runList(cmd, all)
+	},
+}
+
+func init() {
+	listCmd.Flags().BoolP("all", "a", false, "include resolved threads")
+	ThreadCmd.AddCommand(listCmd)
+}
+
+// runList prints the threads of the current game. List numbers always refer to
+// the position among all threads so that they stay stable when filtering.
+func runList(cmd *cobra.Command, all bool) error {
+	g := gdb.Current
+	if g == nil {
+		return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+	}
+
+	threads, err := gdb.GetThreads(g.ID, true)
+	if err != nil {
+		return fmt.Errorf("failed to load threads: %w", err)
+	}
+
+	shown := 0
+	for i, t := range threads {
+		if !all && t.Status != gdb.ThreadActive {
+			continue
+		}
+		if shown == 0 {
+			cmd.Println("Threads:")
+		}
+		shown++
+		line := fmt.Sprintf("  %d. %s", i+1, t.Name)
+		if t.Weight > 1 {
+			line = fmt.Sprintf("%s (x%d)", line, t.Weight)
+		}
+		if t.Status != gdb.ThreadActive {
+			line = fmt.Sprintf("%s [%s]", line, t.Status)
+		}
+		cmd.Println(line)
+		if t.Description != "" {
+			cmd.Printf("     %s\n", t.Description)
+		}
+	}
+	if shown == 0 {
+		cmd.Println("No threads on the list.")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package thread
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// removeCmd deletes a thread from the Threads List entirely.
+// Use 'thread resolve' instead to keep the thread in the game's history.
+var removeCmd = &cobra.Command{
+	Use:               "remove <thread>",
+	Aliases:           []string{"rm", "delete"},
+	Short:             "Remove a thread from the list",
+	Long:              `Remove a thread from the Threads List entirely. Use 'thread resolve' to keep it in the game's history instead.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeThreads,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g := gdb.Current
+		if g == nil {
+			return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+		}
+
+		t, err := gdb.FindThread(g.ID, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if err := db.GamesDB.Delete(t).Error; err != nil {
+			return fmt.Errorf("failed to remove thread: %w", err)
+		}
+
+		msg := fmt.Sprintf("Thread removed: %s", t.Name)
+		if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+			return fmt.Errorf("failed to log thread change: %w", err)
+		}
+		cmd.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	ThreadCmd.AddCommand(removeCmd)
+}
@@ -0,0 +1,49 @@
+// Package thread provides commands for managing the Mythic Threads List of a game.
+package thread
+
+import (
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// ThreadCmd is the root command for Threads List management.
+// When invoked without subcommands, it lists the active threads of the current game.
+var ThreadCmd = &cobra.Command{
+	Use:     "thread",
+	Aliases: []string{"threads", "th"},
+	Short:   "Manage the Threads List",
+	Long: `Manage the Threads List of the current game. Threads are the adventure's
+objectives and goals. A thread can appear on the list up to 3 times (its weight),
+which makes it more likely to be picked when rolling on the list.
+
+Threads can be referred to by their list number (see 'thread list --all') or by name.
+Every change is recorded in the game log.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runList(cmd, false)
+	},
+}
+
+// completeThreads completes the thread argument of a command with the names
+// on the current game's Threads List.
+func completeThreads(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 || gdb.Current == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	threads, err := gdb.GetThreads(gdb.Current.ID, true)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]cobra.Completion, 0, len(threads))
+	for _, t := range threads {
+		names = append(names, t.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWeight completes a thread followed by a weight.
+func completeWeight(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeThreads(cmd, args, toComplete)
+	}
+	return []cobra.Completion{"1", "2", "3"}, cobra.ShellCompDirectiveNoFileComp
+}
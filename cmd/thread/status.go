@@ -0,0 +1,62 @@
+package thread
+
+import (
+	"fmt"
+	"strings"
+
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// resolveCmd marks a thread as resolved, removing it from the active Threads List.
+var resolveCmd = &cobra.Command{
+	Use:               "resolve <thread>",
+	Aliases:           []string{"close"},
+	Short:             "Mark a thread as resolved",
+	Long:              `Mark a thread as resolved. Resolved threads stay in the game's history but are no longer part of the active Threads List.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeThreads,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatus(cmd, strings.Join(args, " "), gdb.ThreadResolved, "Thread resolved")
+	},
+}
+
+// reopenCmd returns a resolved thread to the active Threads List.
+var reopenCmd = &cobra.Command{
+	Use:               "reopen <thread>",
+	Short:             "Reopen a resolved thread",
+	Long:              `Return a resolved thread to the active Threads List.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeThreads,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStatus(cmd, strings.Join(args, " "), gdb.ThreadActive, "Thread reopened")
+	},
+}
+
+func init() {
+	ThreadCmd.AddCommand(resolveCmd)
+	ThreadCmd.AddCommand(reopenCmd)
+}
+
+// setStatus changes the status of the referenced thread and logs the change.
+func setStatus(cmd *cobra.Command, ref, status, label string) error {
+	g := gdb.Current
+	if g == nil {
+		return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+	}
+
+	t, err := gdb.FindThread(g.ID, ref)
+	if err != nil {
+		return err
+	}
+	if err := gdb.SetThreadStatus(t, status); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("%s: %s", label, t.Name)
+	if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+		return fmt.Errorf("failed to log thread change: %w", err)
+	}
+	cmd.Println(msg)
+	return nil
+}
@@ -0,0 +1,83 @@
+package thread
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/spf13/cobra"
+)
+
+// weightCmd sets how many times a thread appears on the Threads List.
+var weightCmd = &cobra.Command{
+	Use:   "weight <thread> <1-3>",
+	Short: "Set how many times a thread appears on the list",
+	Long: `Set the weight of a thread, i.e. how many entries it occupies on the Threads List (1-3).
+Threads with more entries are more likely to be picked when rolling on the list.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeWeight,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("weight requires a thread and a value")
+		}
+		weight, err := strconv.Atoi(args[len(args)-1])
+		if err != nil {
+			return fmt.Errorf("invalid weight: %s", args[len(args)-1])
+		}
+		return setWeight(cmd, strings.Join(args[:len(args)-1], " "), func(int) int { return weight })
+	},
+}
+
+// duplicateCmd adds one more entry of a thread to the Threads List.
+var duplicateCmd = &cobra.Command{
+	Use:               "duplicate <thread>",
+	Aliases:           []string{"dup"},
+	Short:             "Add another entry of a thread to the list",
+	Long:              `Increase the weight of a thread by one, adding another entry for it on the Threads List (maximum 3).`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeThreads,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setWeight(cmd, strings.Join(args, " "), func(w int) int { return w + 1 })
+	},
+}
+
+func init() {
+	ThreadCmd.AddCommand(weightCmd)
+	ThreadCmd.AddCommand(duplicateCmd)
+}
+
+// setWeight applies next to the current weight of the referenced thread,
+// validates the result, and persists and logs the change.
+func setWeight(cmd *cobra.Command, ref string, next func(int) int) error {
+	g := gdb.Current
+	if g == nil {
+		return fmt.Errorf("no game selected. Use 'game load <name>' to select one")
+	}
+
+	t, err := gdb.FindThread(g.ID, ref)
+	if err != nil {
+		return err
+	}
+
+	weight := next(t.Weight)
+	if weight < 1 || weight > gdb.MaxListWeight {
+		return fmt.Errorf("weight must be between 1 and %d", gdb.MaxListWeight)
+	}
+	if weight == t.Weight {
+		cmd.Printf("Thread '%s' already has weight %d\n", t.Name, weight)
+		return nil
+	}
+
+	if err := db.GamesDB.Model(t).Update("weight", weight).Error; err != nil {
+		return fmt.Errorf("failed to update thread: %w", err)
+	}
+
+	msg := fmt.Sprintf("Thread weight: %s (x%d)", t.Name, weight)
+	if _, err := gdb.AddLog(g, gdb.LogTypeList, msg); err != nil {
+		return fmt.Errorf("failed to log thread change: %w", err)
+	}
+	cmd.Println(msg)
+	return nil
+}
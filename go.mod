@@ -4,6 +4,7 @@ go 1.25.5
 
 require (
 	github.com/DMXMax/mge v0.2.6
+	github.com/google/uuid v1.6.0
 	github.com/peterh/liner v1.2.2
 	github.com/rs/zerolog v1.34.0
 	github.com/spf13/cobra v1.10.1
@@ -12,7 +13,6 @@ require (
 )
 
 require (
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
@@ -0,0 +1,249 @@
+// Package config reads and writes the user's settings file,
+// ~/.config/mythic-cli/config.toml (or $XDG_CONFIG_HOME/mythic-cli/config.toml).
+// The file is read as a subset of TOML: one `key = value` setting per line, where the
+// value is a "basic" or 'literal' string or a bare value such as a number, each
+// optionally followed by a # comment. Keys below a [table] header are read as
+// table.key. Saving changes only the lines of the settings that were changed, so
+// comments, tables and the layout of the rest of the file are kept.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config holds the settings read from the config file.
+type Config struct {
+	values map[string]string
+	lines  []string       // Lines of the file, written back by Save
+	index  map[string]int // Line of each setting in lines
+}
+
+// Dir returns the directory holding the config file and other user files
+// such as export templates.
+func Dir() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "mythic-cli"), nil
+}
+
+// Path returns the location of the config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	c := &Config{values: map[string]string{}, index: map[string]int{}}
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		c.lines = []string{"# mythic-cli settings"}
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	table := ""
+	for n := 1; scanner.Scan(); n++ {
+		c.lines = append(c.lines, scanner.Text())
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header, _, _ := strings.Cut(line, "#")
+			header = strings.TrimSpace(header)
+			if !strings.HasSuffix(header, "]") {
+				return nil, fmt.Errorf("%s:%d: expected ] at end of table header", path, n)
+			}
+			table = strings.TrimSpace(strings.Trim(header, "[]"))
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, n)
+		}
+		key = strings.TrimSpace(key)
+		if table != "" {
+			key = table + "." + key
+		}
+		if value, _, err = parseValue(strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid value for %s: %w", path, n, key, err)
+		}
+		c.values[key] = value
+		c.index[key] = n - 1
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// parseValue parses the value of a setting: a "basic" string with escapes, a 'literal'
+// string, or a bare value such as a number or boolean. It returns the comment that may
+// follow the value, or "" if there is none.
+func parseValue(s string) (value, comment string, err error) {
+	var rest string
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := -1
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+		v, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return "", "", fmt.Errorf("invalid string")
+		}
+		value, rest = v, s[end+1:]
+	case strings.HasPrefix(s, "'"):
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+		value, rest = s[1:end+1], s[end+2:]
+	default:
+		v, c, found := strings.Cut(s, "#")
+		if found {
+			comment = "#" + c
+		}
+		return strings.TrimSpace(v), comment, nil
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", "", fmt.Errorf("unexpected %q after string", rest)
+	}
+	return value, rest, nil
+}
+
+// formatValue writes a value for the config file: whole numbers and booleans bare,
+// everything else as a quoted string.
+func formatValue(value string) string {
+	if _, err := strconv.Atoi(value); err == nil || value == "true" || value == "false" {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// Get returns the value of a setting and whether it is set.
+func (c *Config) Get(key string) (string, bool) {
+	v, ok := c.values[key]
+	return v, ok
+}
+
+// Set changes the value of a setting. A setting that is already in the file keeps
+// its line, including its comment; a new one is added after the last setting that
+// is not in a table.
+func (c *Config) Set(key, value string) {
+	c.values[key] = value
+	if i, ok := c.index[key]; ok {
+		prefix, old, _ := strings.Cut(c.lines[i], "=")
+		line := strings.TrimRight(prefix, " \t") + " = " + formatValue(value)
+		if _, comment, _ := parseValue(strings.TrimSpace(old)); comment != "" {
+			line += " " + comment
+		}
+		c.lines[i] = line
+		return
+	}
+
+	// Add the setting right after the last setting above the first table, or else
+	// before the blank lines ahead of the table
+	table := len(c.lines)
+	for i, line := range c.lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "[") {
+			table = i
+			break
+		}
+	}
+	at := -1
+	for _, i := range c.index {
+		if i < table && i >= at {
+			at = i + 1
+		}
+	}
+	if at < 0 {
+		at = table
+		for at > 0 && strings.TrimSpace(c.lines[at-1]) == "" {
+			at--
+		}
+	}
+	c.lines = slices.Insert(c.lines, at, key+" = "+formatValue(value))
+	for k, i := range c.index {
+		if i >= at {
+			c.index[k] = i + 1
+		}
+	}
+	c.index[key] = at
+}
+
+// Unset removes a setting together with its line.
+func (c *Config) Unset(key string) {
+	delete(c.values, key)
+	at, ok := c.index[key]
+	if !ok {
+		return
+	}
+	delete(c.index, key)
+	c.lines = slices.Delete(c.lines, at, at+1)
+	for k, i := range c.index {
+		if i > at {
+			c.index[k] = i - 1
+		}
+	}
+}
+
+// Keys returns the names of all settings in alphabetical order.
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Save writes the config file, creating its directory if needed.
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data := strings.Join(c.lines, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig points the config directory at a temporary one and writes the config file.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "mythic-cli", "config.toml")
+	if content != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	writeConfig(t, `# my settings
+default_odds = "likely" # a note
+prompt = 'lit {game}> '   # literal
+history_file = "~/.hist \"x\" # not a comment"
+default_chaos = 6
+
+[ui]
+theme = "dark"
+`)
+	c, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"default_odds":  "likely",
+		"prompt":        "lit {game}> ",
+		"history_file":  `~/.hist "x" # not a comment`,
+		"default_chaos": "6",
+		"ui.theme":      "dark",
+	}
+	for k, v := range want {
+		if got, ok := c.Get(k); !ok || got != v {
+			t.Errorf("Get(%q) = %q, %v, want %q", k, got, ok, v)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, content := range []string{
+		"default_odds\n",
+		`default_odds = "likely" junk` + "\n",
+		`prompt = "open` + "\n",
+		"[ui\n",
+	} {
+		writeConfig(t, content)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() of %q succeeded, want an error", content)
+		}
+	}
+}
+
+func TestSaveKeepsFile(t *testing.T) {
+	path := writeConfig(t, `# my settings
+default_odds = "likely" # a note
+default_chaos = 6
+log_page_size = 10
+
+# interface
+[ui]
+prompt = "> "
+`)
+	c, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("default_odds", "very likely")
+	c.Set("default_chaos", "7")
+	c.Unset("log_page_size")
+	c.Set("oracle", "fatecheck")
+	c.Set("ui.prompt", "$ ")
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# my settings
+default_odds = "very likely" # a note
+default_chaos = 7
+oracle = "fatecheck"
+
+# interface
+[ui]
+prompt = "$ "
+`
+	if string(data) != want {
+		t.Errorf("saved file:\n%s\nwant:\n%s", data, want)
+	}
+
+	c, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{"default_odds": "very likely", "default_chaos": "7", "oracle": "fatecheck", "ui.prompt": "$ "} {
+		if got, _ := c.Get(k); got != v {
+			t.Errorf("Get(%q) after saving = %q, want %q", k, got, v)
+		}
+	}
+	if _, ok := c.Get("log_page_size"); ok {
+		t.Error("log_page_size is still set after Unset")
+	}
+}
+
+func TestSaveNewFile(t *testing.T) {
+	path := writeConfig(t, "")
+	c, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("db", "horror")
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# mythic-cli settings\ndb = \"horror\"\n"; string(data) != want {
+		t.Errorf("saved file = %q, want %q", data, want)
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+)
+
+// Names of the settings
+const (
+	DB             = "db"              // Database name or file
+	DefaultChaos   = "default_chaos"   // Chaos factor (1-9) of new games
+	DefaultOdds    = "default_odds"    // Odds of a roll without -o
+	LogPageSize    = "log_page_size"   // Number of entries 'gamelog print' shows
+	ExportTemplate = "export_template" // Template file used by 'game export'
+	Prompt         = "prompt"          // Shell prompt while a game is loaded
+	HistoryFile    = "history_file"    // File the shell history is kept in
+	Oracle         = "oracle"          // Oracle of games that have not chosen one
+)
+
+// Setting describes a setting of the config file.
+type Setting struct {
+	Name        string
+	Default     string
+	Description string
+	// Validate checks a new value, or is nil if any value is accepted.
+	Validate func(value string) error
+}
+
+// Settings lists the known settings in the order they are shown.
+var Settings = []Setting{
+	{DB, "games", "database name (in ~/.mythic-db) or file, see 'db use'", nil},
+	{DefaultChaos, "5", "chaos factor (1-9) of new games and of rolls without a game", validateChaos},
+	{DefaultOdds, "fifty fifty", "odds of a roll without -o (name or number 0-8)", validateOdds},
+	{LogPageSize, "20", "number of entries 'gamelog print' shows", validatePositive},
+	{ExportTemplate, "full", "template 'game export' uses: a name from 'game export --list-templates' or a file", nil},
+	{Prompt, "{game} (C:{chaos})> ", "shell prompt while a game is loaded; {game}, {chaos} and {db} are replaced", nil},
+	{HistoryFile, "~/.mythic-cli_history", "file the shell history is kept in", nil},
+	{Oracle, oracle.Default, "oracle of games that have not chosen one (see 'game oracle')", validateOracle},
+}
+
+// Lookup returns the setting with the given name.
+func Lookup(name string) (*Setting, error) {
+	for i := range Settings {
+		if Settings[i].Name == name {
+			return &Settings[i], nil
+		}
+	}
+	names := make([]string, len(Settings))
+	for i, s := range Settings {
+		names[i] = s.Name
+	}
+	return nil, fmt.Errorf("unknown setting: %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Value returns the configured value of a setting, or its default if it is not set.
+func (c *Config) Value(name string) string {
+	if v, ok := c.values[name]; ok {
+		return v
+	}
+	if s, err := Lookup(name); err == nil {
+		return s.Default
+	}
+	return ""
+}
+
+// Int returns the value of a numeric setting.
+func (c *Config) Int(name string) (int, error) {
+	v := c.Value(name)
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("setting %s: %q is not a number", name, v)
+	}
+	return n, nil
+}
+
+// File returns the value of a setting that names a file, with a leading ~
+// standing for the home directory.
+func (c *Config) File(name string) (string, error) {
+	v := c.Value(name)
+	if v == "~" || strings.HasPrefix(v, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		v = filepath.Join(home, v[1:])
+	}
+	return v, nil
+}
+
+// validateChaos accepts a user-facing chaos factor (1-9).
+func validateChaos(v string) error {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < chart.MinChaosUser || n > chart.MaxChaosUser {
+		return fmt.Errorf("chaos must be between %d and %d", chart.MinChaosUser, chart.MaxChaosUser)
+	}
+	return nil
+}
+
+// validateOdds accepts odds by name or number.
+func validateOdds(v string) error {
+	_, err := oracle.ParseOdds(v)
+	return err
+}
+
+// validatePositive accepts a positive number.
+func validatePositive(v string) error {
+	if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+		return fmt.Errorf("must be a positive number")
+	}
+	return nil
+}
+
+// validateOracle accepts the name or alias of an oracle.
+func validateOracle(v string) error {
+	_, err := oracle.Find(v)
+	return err
+}
@@ -3,9 +3,58 @@
 package db
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"gorm.io/gorm"
 )
 
 // GamesDB is the global database connection instance.
-// It is initialized in main.init() and used by all database operations.
+// It is opened by the root command before any command runs and used by all database operations.
 var GamesDB *gorm.DB
+
+// Path is the file GamesDB was opened from.
+var Path string
+
+// DefaultName is the name of the database used when none is configured.
+const DefaultName = "games"
+
+// Dir returns the directory that holds named databases, ~/.mythic-db.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".mythic-db"), nil
+}
+
+// Resolve returns the file of a database given by name or path. A plain name such
+// as "horror" refers to ~/.mythic-db/horror.db; anything containing a path separator
+// or ending in .db is a file path, where a leading ~ stands for the home directory.
+func Resolve(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("no database name given")
+	}
+	if !strings.ContainsRune(name, filepath.Separator) && !strings.ContainsRune(name, '/') && filepath.Ext(name) != ".db" {
+		dir, err := Dir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, name+".db"), nil
+	}
+	if name == "~" || strings.HasPrefix(name, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		name = filepath.Join(home, name[1:])
+	}
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid database path %q: %w", name, err)
+	}
+	return path, nil
+}
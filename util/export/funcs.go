@@ -0,0 +1,164 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DMXMax/mge/chart"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+)
+
+// Funcs returns the functions available to export templates. Functions that take a
+// text or a list take it last, so they can be used in pipelines, e.g.
+// {{.Msg | wrap 72 | indent "> "}} or {{range .Log | ofType "story"}}.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"formatTime": func(t time.Time, layout string) string { return t.Format(layout) },
+		"oddsName":   oddsName,
+		"escape":     escape,
+		"cell":       cell,
+		"wrap":       wrap,
+		"indent":     indent,
+		"ofType":     ofType,
+		"byDay":      byDay,
+		"yesno":      yesno,
+	}
+}
+
+// Day holds the entries recorded on one day.
+type Day struct {
+	Date    time.Time // Midnight at the start of the day, local time
+	Entries []Entry
+}
+
+// oddsName turns a numeric odds value (0-8) into its name, e.g. "likely".
+func oddsName(v int) string {
+	if v < 0 || v >= len(chart.OddsStrList) {
+		return strconv.Itoa(v)
+	}
+	return chart.OddsStrList[v]
+}
+
+// yesno turns an optional answer, such as whether the PCs were in control of a
+// scene, into "yes", "no" or "" if it is not known.
+func yesno(b *bool) string {
+	switch {
+	case b == nil:
+		return ""
+	case *b:
+		return "yes"
+	}
+	return "no"
+}
+
+// markdownEscaper escapes the characters with a meaning anywhere in a Markdown line.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `|`, `\|`,
+)
+
+// Markers that start a heading, quote, list or numbered list at the beginning of a line
+var (
+	blockMarker    = regexp.MustCompile(`(?m)^(\s*)([#>+-])`)
+	numberedMarker = regexp.MustCompile(`(?m)^(\s*\d+)\.`)
+)
+
+// escape escapes a text so Markdown shows it as written, e.g. a message containing
+// *asterisks* or starting with #.
+func escape(s string) string {
+	s = markdownEscaper.Replace(s)
+	s = blockMarker.ReplaceAllString(s, `${1}\${2}`)
+	return numberedMarker.ReplaceAllString(s, `${1}\.`)
+}
+
+// cell escapes a text for a Markdown table cell, where line breaks become <br>.
+func cell(s string) string {
+	return strings.ReplaceAll(escape(strings.TrimSpace(s)), "\n", "<br>")
+}
+
+// wrap breaks the lines of a text at spaces so they are at most width characters long.
+// Words longer than width are kept whole.
+func wrap(width int, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if width <= 0 || utf8.RuneCountInString(line) <= width {
+			continue
+		}
+		var b strings.Builder
+		n := 0
+		for _, word := range strings.Fields(line) {
+			w := utf8.RuneCountInString(word)
+			if n > 0 && n+1+w > width {
+				b.WriteByte('\n')
+				n = 0
+			} else if n > 0 {
+				b.WriteByte(' ')
+				n++
+			}
+			b.WriteString(word)
+			n += w
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indent puts prefix in front of every line of a text, e.g. "> " to quote it.
+func indent(prefix, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ofType returns the entries of the given types: a comma-separated list of type names
+// (story, roll, scene_start, scene_end, event, list) or numbers.
+func ofType(types string, entries []Entry) ([]Entry, error) {
+	want := map[int]bool{}
+	for _, t := range strings.Split(types, ",") {
+		t = strings.TrimSpace(t)
+		if n, err := strconv.Atoi(t); err == nil {
+			want[n] = true
+			continue
+		}
+		found := false
+		for n, name := range gdb.LogTypeNames {
+			if name == t {
+				want[n], found = true, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown entry type: %q", t)
+		}
+	}
+	var out []Entry
+	for _, e := range entries {
+		if want[e.Type] {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// byDay groups entries by the day they were recorded on, keeping their order.
+func byDay(entries []Entry) []Day {
+	var days []Day
+	for _, e := range entries {
+		t := e.CreatedAt.Local()
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, Day{Date: date})
+		}
+		days[len(days)-1].Entries = append(days[len(days)-1].Entries, e)
+	}
+	return days
+}
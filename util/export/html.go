@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed html
+var htmlFS embed.FS
+
+// htmlPage is the data of a page's layout.
+type htmlPage struct {
+	Title      string
+	Style      template.CSS // Inline CSS of a single-file page
+	Stylesheet string       // Link to the site's style sheet
+	Nav        []htmlLink   // Links to the pages above this one
+	Data       any          // Data of the page's body
+}
+
+// htmlLink is a link in a page's navigation.
+type htmlLink struct {
+	Href string
+	Text string
+}
+
+// siteGame is a game published on the site, with the directory of its pages.
+type siteGame struct {
+	*Game
+	Slug string
+}
+
+// sitePage is a page of a game on the site.
+type sitePage struct {
+	file  string     // File name within the game's directory
+	page  string     // Name of the page template
+	title string     // Page title
+	data  any        // Data of the page's body
+	nav   []htmlLink // Links to the pages above
+}
+
+// sceneData is the data of a scene page.
+type sceneData struct {
+	*Game
+	Scene      *Scene
+	Prev, Next *Scene
+}
+
+// WriteHTML writes a game as a single HTML page with inline CSS.
+func WriteHTML(w io.Writer, g *Game) error {
+	css, err := htmlFS.ReadFile("html/style.css")
+	if err != nil {
+		return fmt.Errorf("failed to read style sheet: %w", err)
+	}
+	return renderHTML(w, "game", htmlPage{Title: g.Name, Style: template.CSS(css), Data: g})
+}
+
+// Publish writes a static site for the games to dir: an index of the games and, for
+// each game, an overview, its log, a page per scene, and its threads and characters.
+// It returns the files it wrote.
+func Publish(dir string, games []*Game) ([]string, error) {
+	var written []string
+	write := func(name, page string, p htmlPage) error {
+		var buf bytes.Buffer
+		if err := renderHTML(&buf, page, p); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	css, err := htmlFS.ReadFile("html/style.css")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read style sheet: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), css, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write style sheet: %w", err)
+	}
+	written = append(written, filepath.Join(dir, "style.css"))
+
+	site := make([]siteGame, len(games))
+	used := map[string]bool{}
+	for i, g := range games {
+		site[i] = siteGame{Game: g, Slug: slug(g.Name, used)}
+	}
+	if err := write("index.html", "index", htmlPage{Title: "Campaigns", Stylesheet: "style.css", Data: site}); err != nil {
+		return nil, err
+	}
+
+	for _, sg := range site {
+		home := []htmlLink{{Href: "../index.html", Text: "Campaigns"}}
+		up := []htmlLink{home[0], {Href: "index.html", Text: sg.Name}}
+		pages := []sitePage{
+			{"index.html", "overview", sg.Name, sg, home},
+			{"log.html", "log", sg.Name + ": Game Log", sg, up},
+			{"threads.html", "threads", sg.Name + ": Threads", sg, up},
+			{"characters.html", "characters", sg.Name + ": Characters", sg, up},
+		}
+		for i, s := range sg.Scenes {
+			data := sceneData{Game: sg.Game, Scene: s}
+			if i > 0 {
+				data.Prev = sg.Scenes[i-1]
+			}
+			if i < len(sg.Scenes)-1 {
+				data.Next = sg.Scenes[i+1]
+			}
+			title := fmt.Sprintf("%s: Scene %d", sg.Name, s.Number)
+			pages = append(pages, sitePage{fmt.Sprintf("scene-%d.html", s.Number), "scene", title, data, up})
+		}
+		for _, p := range pages {
+			page := htmlPage{Title: p.title, Stylesheet: "../style.css", Nav: p.nav, Data: p.data}
+			if err := write(filepath.Join(sg.Slug, p.file), p.page, page); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// renderHTML renders a page with the shared layout and building blocks.
+func renderHTML(w io.Writer, page string, p htmlPage) error {
+	tpl, err := template.New(page).Funcs(template.FuncMap(Funcs())).
+		ParseFS(htmlFS, "html/layout.html.tmpl", "html/partials.html.tmpl", "html/"+page+".html.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse %s page: %w", page, err)
+	}
+	if err := tpl.ExecuteTemplate(w, "page", p); err != nil {
+		return fmt.Errorf("failed to render %s page: %w", page, err)
+	}
+	return nil
+}
+
+// slug turns a game name into a directory name that is not in used yet, e.g.
+// "Kat in Shadow" into "kat-in-shadow".
+func slug(name string, used map[string]bool) string {
+	base := strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if base == "" {
+		base = "game"
+	}
+	s := base
+	for n := 2; used[s]; n++ {
+		s = fmt.Sprintf("%s-%d", base, n)
+	}
+	used[s] = true
+	return s
+}
@@ -0,0 +1,236 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mge/util/theme"
+	"github.com/DMXMax/mythic-cli/util/db"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/google/uuid"
+)
+
+// Game is the root object export templates are rendered with: a game together with
+// its scenes, lists, log and statistics.
+type Game struct {
+	ID          uuid.UUID
+	Name        string
+	Chaos       int    // User-facing chaos factor (1-9)
+	Oracle      string // Name of the game's yes/no oracle
+	StoryThemes theme.Themes
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ExportedAt  time.Time
+	Log         []Entry         // All log entries, oldest first
+	Scenes      []*Scene        // Scenes in the order they were played
+	Threads     []gdb.Thread    // Threads List, including resolved threads
+	Characters  []gdb.Character // Characters List, including retired characters
+	Stats       Stats
+}
+
+// Entry is a log entry.
+type Entry struct {
+	ID        uuid.UUID
+	Type      int    // One of the gdb.LogType constants
+	TypeName  string // story, roll, scene_start, scene_end, event or list
+	Msg       string
+	CreatedAt time.Time
+	Scene     int   // Number of the scene the entry was recorded in, 0 if none
+	Roll      *Roll // Structured result of a dice roll entry, if known
+}
+
+// Roll is the structured result of a roll, with the odds of an oracle question by name.
+type Roll struct {
+	*gdb.RollDetail
+	OddsName string
+}
+
+// Scene is a scene with the entries recorded during it.
+type Scene struct {
+	ID              uuid.UUID
+	Number          int
+	Type            string // expected, altered or interrupt
+	ExpectedConcept string
+	ChaosDieRoll    int
+	Adjustment      string // Scene Adjustment of an altered scene
+	Event           string // Random Event that interrupted an interrupt scene
+	Summary         string
+	PCInControl     *bool // nil until the scene ends
+	Active          bool
+	StartedAt       time.Time
+	EndedAt         time.Time // Zero while the scene is active
+	Entries         []Entry   // Entries recorded during the scene, oldest first
+}
+
+// Stats counts what happened in a game.
+type Stats struct {
+	Entries      int
+	Story        int
+	Rolls        int
+	Events       int            // Random events
+	Answers      map[string]int // Number of oracle answers, e.g. "Yes": 3
+	Scenes       int
+	SceneTypes   map[string]int // Number of scenes by type, e.g. "altered": 1
+	Threads      int            // Active threads
+	Resolved     int            // Resolved threads
+	Characters   int            // Active characters
+	Days         int            // Days with at least one entry
+	FirstEntry   time.Time
+	LastEntry    time.Time
+	PCsInControl int // Ended scenes in which the PCs were in control
+}
+
+// NewGame loads everything templates can show about a game.
+func NewGame(g *gdb.Game) (*Game, error) {
+	oracleName, err := gdb.GameOracle(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := &Game{
+		ID:          g.ID,
+		Name:        g.Name,
+		Chaos:       chart.ChaosInternalToUser(int(g.Chaos)),
+		Oracle:      oracleName,
+		StoryThemes: g.StoryThemes,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+		ExportedAt:  time.Now(),
+	}
+
+	if out.Threads, err = gdb.GetThreads(g.ID, true); err != nil {
+		return nil, fmt.Errorf("failed to load threads: %w", err)
+	}
+	if out.Characters, err = gdb.GetCharacters(g.ID, true); err != nil {
+		return nil, fmt.Errorf("failed to load characters: %w", err)
+	}
+
+	records, err := gdb.GetScenes(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	scenes := make(map[uuid.UUID]*Scene, len(records))
+	for _, r := range records {
+		s := &Scene{
+			ID:              r.ID,
+			Number:          r.Detail.Number,
+			Type:            r.Type,
+			ExpectedConcept: r.ExpectedConcept,
+			ChaosDieRoll:    r.ChaosDieRoll,
+			Adjustment:      r.Detail.Adjustment,
+			Event:           r.Detail.Event,
+			Summary:         r.Detail.Summary,
+			PCInControl:     r.Detail.PCInControl,
+			Active:          r.IsActive,
+			StartedAt:       r.CreatedAt,
+		}
+		scenes[r.ID] = s
+		out.Scenes = append(out.Scenes, s)
+	}
+
+	entries, err := loadEntries(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	details, err := gdb.GetLogEntryDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+	rolls, err := gdb.GetRollDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		entry := Entry{ID: e.ID, Type: e.Type, TypeName: gdb.LogTypeNames[e.Type], Msg: e.Msg, CreatedAt: e.CreatedAt}
+		if r, ok := rolls[e.ID]; ok {
+			entry.Roll = &Roll{RollDetail: &r}
+			if r.Kind == gdb.RollKindOracle {
+				entry.Roll.OddsName = chart.Odds(r.Odds).String()
+			}
+		}
+		var scene *Scene
+		if d, ok := details[e.ID]; ok && d.SceneID != nil {
+			scene = scenes[*d.SceneID]
+		}
+		if scene != nil {
+			entry.Scene = scene.Number
+			if e.Type == gdb.LogTypeSceneEnd && !scene.Active {
+				scene.EndedAt = e.CreatedAt
+			}
+			scene.Entries = append(scene.Entries, entry)
+		}
+		out.Log = append(out.Log, entry)
+	}
+
+	out.Stats = newStats(out)
+	return out, nil
+}
+
+// loadEntries returns the log entries of a game, oldest first, leaving out copies of
+// an entry that was saved more than once (same message, type and second).
+func loadEntries(gameID uuid.UUID) ([]gdb.LogEntry, error) {
+	var entries []gdb.LogEntry
+	if err := db.GamesDB.Where("game_id = ?", gameID).Order("created_at ASC").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load log entries: %w", err)
+	}
+	seen := make(map[string]bool)
+	unique := make([]gdb.LogEntry, 0, len(entries))
+	for _, e := range entries {
+		key := fmt.Sprintf("%s|%d|%s", e.Msg, e.Type, e.CreatedAt.Format("2006-01-02 15:04:05"))
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, e)
+		}
+	}
+	return unique, nil
+}
+
+// newStats counts the entries, scenes and lists of a game.
+func newStats(g *Game) Stats {
+	s := Stats{Answers: map[string]int{}, SceneTypes: map[string]int{}, Entries: len(g.Log), Scenes: len(g.Scenes)}
+	days := map[string]bool{}
+	for _, e := range g.Log {
+		switch e.Type {
+		case gdb.LogTypeStory:
+			s.Story++
+		case gdb.LogTypeDiceRoll:
+			s.Rolls++
+			if e.Roll != nil && e.Roll.Kind == gdb.RollKindOracle && e.Roll.Answer != "" {
+				s.Answers[e.Roll.Answer]++
+			}
+		case gdb.LogTypeEvent:
+			s.Events++
+		}
+		days[e.CreatedAt.Format(time.DateOnly)] = true
+	}
+	if len(g.Log) > 0 {
+		s.FirstEntry = g.Log[0].CreatedAt
+		s.LastEntry = g.Log[len(g.Log)-1].CreatedAt
+	}
+	s.Days = len(days)
+	for _, sc := range g.Scenes {
+		s.SceneTypes[strings.ToLower(sc.Type)]++
+		if sc.PCInControl != nil && *sc.PCInControl {
+			s.PCsInControl++
+		}
+	}
+	for _, t := range g.Threads {
+		switch t.Status {
+		case gdb.ThreadActive:
+			s.Threads++
+		case gdb.ThreadResolved:
+			s.Resolved++
+		}
+	}
+	for _, c := range g.Characters {
+		if c.Status == gdb.CharacterActive {
+			s.Characters++
+		}
+	}
+	return s
+}
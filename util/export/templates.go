@@ -0,0 +1,153 @@
+// Package export holds the templates 'game export' renders games with. A set of
+// built-in templates is embedded in the binary; templates in the user template
+// directory (~/.config/mythic-cli/templates) add to them or replace them by name.
+package export
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/DMXMax/mythic-cli/util/config"
+)
+
+// Ext is the file extension of export templates.
+const Ext = ".md.tmpl"
+
+// Template sources
+const (
+	SourceBuiltin = "built-in"
+	SourceUser    = "user"
+)
+
+//go:embed templates/*.md.tmpl
+var builtin embed.FS
+
+// Template describes an export template that can be chosen by name.
+type Template struct {
+	Name        string // Name used with 'game export -t'
+	Description string // Taken from a {{/* ... */}} comment on the first line
+	Source      string // SourceBuiltin or SourceUser
+	Path        string // File of a user template
+	Overrides   bool   // Whether a user template replaces a built-in one
+}
+
+// UserDir returns the directory holding the user's templates.
+func UserDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "templates"), nil
+}
+
+// List returns the built-in and user templates sorted by name. A user template
+// with the name of a built-in one takes its place.
+func List() ([]Template, error) {
+	byName := map[string]Template{}
+	files, err := builtin.ReadDir("templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in templates: %w", err)
+	}
+	for _, f := range files {
+		text, err := builtin.ReadFile("templates/" + f.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read built-in template %s: %w", f.Name(), err)
+		}
+		name := strings.TrimSuffix(f.Name(), Ext)
+		byName[name] = Template{Name: name, Description: description(text), Source: SourceBuiltin}
+	}
+
+	dir, err := UserDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user templates: %w", err)
+	}
+	for _, p := range paths {
+		text, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", p, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(p), Ext)
+		_, overrides := byName[name]
+		byName[name] = Template{Name: name, Description: description(text), Source: SourceUser, Path: p, Overrides: overrides}
+	}
+
+	list := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Load parses the template named by ref. ref is either the name of a user or
+// built-in template, or the path of a template file.
+func Load(ref string) (*template.Template, error) {
+	name, text, err := read(ref)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := template.New(name).Funcs(Funcs()).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", ref, err)
+	}
+	return tpl, nil
+}
+
+// read returns the name and text of the template named by ref.
+func read(ref string) (string, []byte, error) {
+	// A path, either by its form or because the file exists
+	isPath := strings.ContainsRune(ref, os.PathSeparator) || strings.HasSuffix(ref, ".tmpl")
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		isPath = true
+	}
+	if isPath {
+		text, err := os.ReadFile(ref)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read template '%s': %w", ref, err)
+		}
+		return filepath.Base(ref), text, nil
+	}
+
+	// A user template, which may replace a built-in one
+	dir, err := UserDir()
+	if err != nil {
+		return "", nil, err
+	}
+	text, err := os.ReadFile(filepath.Join(dir, ref+Ext))
+	if err == nil {
+		return ref, text, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", nil, fmt.Errorf("failed to read template '%s': %w", ref, err)
+	}
+
+	text, err = builtin.ReadFile("templates/" + ref + Ext)
+	if err != nil {
+		return "", nil, fmt.Errorf("unknown template '%s' (see 'game export --list-templates')", ref)
+	}
+	return ref, text, nil
+}
+
+// descriptionPattern matches a template comment, with or without trim markers.
+var descriptionPattern = regexp.MustCompile(`^\{\{-?\s*/\*(.*)\*/\s*-?\}\}$`)
+
+// description returns the text of a {{/* ... */}} comment on the first line.
+func description(text []byte) string {
+	line, _, _ := strings.Cut(string(text), "\n")
+	m := descriptionPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(m[1])
+}
@@ -0,0 +1,540 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/DMXMax/mge/storage"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/google/uuid"
+)
+
+// Markers around the generated part of a vault note. Everything outside them, and
+// frontmatter properties the exporter does not write, belong to the user.
+const (
+	vaultBegin = "<!-- mythic-cli:begin (generated; edits here are replaced) -->"
+	vaultEnd   = "<!-- mythic-cli:end -->"
+)
+
+// vaultLists are the list properties the user may add entries of their own to, such
+// as tags. Their entries are merged with the generated ones instead of replaced.
+var vaultLists = map[string]bool{"tags": true, "aliases": true}
+
+//go:embed vault/*.md.tmpl
+var vaultFS embed.FS
+
+// VaultResult counts the notes written by WriteVault.
+type VaultResult struct {
+	Dir       string // Folder of the campaign within the vault
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// vaultLink is a [[wikilink]] to a note, shown with its text.
+type vaultLink struct {
+	Note string
+	Text string
+}
+
+// linkTextReplacer removes what would end a wikilink early from its text.
+var linkTextReplacer = strings.NewReplacer("|", "-", "]]", "]")
+
+// String returns the link in wikilink syntax.
+func (l vaultLink) String() string {
+	if l.Text == "" || l.Text == l.Note {
+		return "[[" + l.Note + "]]"
+	}
+	return "[[" + l.Note + "|" + linkTextReplacer.Replace(l.Text) + "]]"
+}
+
+// vaultCampaign is a game together with the links between its notes.
+type vaultCampaign struct {
+	*Game
+	Link       vaultLink
+	Scenes     []*vaultScene
+	Threads    []*vaultItem
+	Characters []*vaultItem
+	Loose      []Entry // Story entries, rolls and events outside any scene
+}
+
+// vaultScene is a scene and the threads and characters mentioned in it.
+type vaultScene struct {
+	*Scene
+	Link       vaultLink
+	Prev, Next *vaultLink
+	Threads    []vaultLink
+	Characters []vaultLink
+}
+
+// vaultItem is a thread or character and the scenes that mention it.
+type vaultItem struct {
+	ID          uuid.UUID
+	Kind        string // Thread or Character
+	Name        string
+	Description string
+	Notes       string
+	Weight      int
+	Status      string
+	Link        vaultLink
+	Scenes      []vaultLink
+}
+
+// vaultPage is the data of a note template.
+type vaultPage struct {
+	Campaign *vaultCampaign
+	Scene    *vaultScene
+	Item     *vaultItem
+}
+
+// vaultNote is a note to write: its frontmatter properties and generated text.
+type vaultNote struct {
+	id     uuid.UUID
+	path   string // Path relative to the campaign folder
+	fields []vaultField
+	text   string
+}
+
+// vaultField is a frontmatter property with its value in YAML.
+type vaultField struct {
+	key   string
+	value string
+}
+
+// WriteVault writes a game as notes for Obsidian or Logseq into a folder named after
+// it in dir: a campaign index note and one note per scene, thread and character, with
+// YAML frontmatter and [[wikilinks]] between them. Running it again updates the notes
+// in place: only the frontmatter properties and the part between the generated
+// markers are replaced, so notes and properties added by the user are kept. Notes
+// are found by their mythic_id, so they are moved when a scene or item is renamed.
+func WriteVault(dir string, g *Game) (*VaultResult, error) {
+	tpl, err := template.New("vault").Funcs(Funcs()).ParseFS(vaultFS, "vault/*.md.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vault templates: %w", err)
+	}
+	c := newVaultCampaign(g)
+
+	var notes []vaultNote
+	render := func(name string, page vaultPage) (string, error) {
+		var buf bytes.Buffer
+		if err := tpl.ExecuteTemplate(&buf, name, page); err != nil {
+			return "", fmt.Errorf("failed to render %s note: %w", strings.TrimSuffix(name, ".md.tmpl"), err)
+		}
+		return strings.TrimSpace(buf.String()), nil
+	}
+
+	text, err := render("campaign.md.tmpl", vaultPage{Campaign: c})
+	if err != nil {
+		return nil, err
+	}
+	notes = append(notes, vaultNote{id: g.ID, path: c.Link.Note + ".md", text: text, fields: []vaultField{
+		{"type", yamlValue("campaign")},
+		{"mythic_id", yamlValue(g.ID.String())},
+		{"chaos", yamlValue(g.Chaos)},
+		{"oracle", yamlValue(g.Oracle)},
+		{"themes", yamlValue(themeNames(g))},
+		{"scenes", yamlValue(len(g.Scenes))},
+		{"created", yamlValue(g.CreatedAt)},
+		{"updated", yamlValue(g.UpdatedAt)},
+		{"tags", yamlValue([]string{"mythic", "campaign"})},
+	}})
+
+	for _, s := range c.Scenes {
+		text, err := render("scene.md.tmpl", vaultPage{Campaign: c, Scene: s})
+		if err != nil {
+			return nil, err
+		}
+		var ended any
+		if !s.EndedAt.IsZero() {
+			ended = s.EndedAt
+		}
+		notes = append(notes, vaultNote{id: s.ID, path: filepath.Join("Scenes", s.Link.Note+".md"), text: text, fields: []vaultField{
+			{"type", yamlValue("scene")},
+			{"mythic_id", yamlValue(s.ID.String())},
+			{"campaign", yamlValue(c.Link.String())},
+			{"scene", yamlValue(s.Number)},
+			{"scene_type", yamlValue(s.Type)},
+			{"concept", yamlValue(s.ExpectedConcept)},
+			{"chaos_die", yamlValue(s.ChaosDieRoll)},
+			{"adjustment", yamlValue(s.Adjustment)},
+			{"event", yamlValue(s.Event)},
+			{"pcs_in_control", yamlValue(s.PCInControl)},
+			{"started", yamlValue(s.StartedAt)},
+			{"ended", yamlValue(ended)},
+			{"tags", yamlValue([]string{"mythic", "scene"})},
+		}})
+	}
+
+	for _, items := range [][]*vaultItem{c.Threads, c.Characters} {
+		for _, it := range items {
+			text, err := render("item.md.tmpl", vaultPage{Campaign: c, Item: it})
+			if err != nil {
+				return nil, err
+			}
+			kind := strings.ToLower(it.Kind)
+			notes = append(notes, vaultNote{id: it.ID, path: filepath.Join(it.Kind+"s", it.Link.Note+".md"), text: text, fields: []vaultField{
+				{"type", yamlValue(kind)},
+				{"mythic_id", yamlValue(it.ID.String())},
+				{"campaign", yamlValue(c.Link.String())},
+				{"status", yamlValue(it.Status)},
+				{"weight", yamlValue(it.Weight)},
+				{"aliases", yamlValue([]string{it.Name})},
+				{"tags", yamlValue([]string{"mythic", kind})},
+			}})
+		}
+	}
+
+	res := &VaultResult{Dir: filepath.Join(dir, c.Link.Note)}
+	existing, err := findVaultNotes(res.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		if err := writeVaultNote(res, existing[n.id], n); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// newVaultCampaign names the notes of a game and finds the threads and characters
+// mentioned in each scene.
+func newVaultCampaign(g *Game) *vaultCampaign {
+	c := &vaultCampaign{Game: g, Link: vaultLink{Note: noteName(g.Name)}}
+	prefix := c.Link.Note + " - "
+	for _, t := range g.Threads {
+		c.Threads = append(c.Threads, &vaultItem{
+			ID: t.ID, Kind: "Thread", Name: t.Name, Description: t.Description, Weight: t.Weight, Status: t.Status,
+			Link: vaultLink{Note: prefix + noteName(t.Name), Text: t.Name},
+		})
+	}
+	threadNotes := map[string]bool{}
+	for _, t := range c.Threads {
+		threadNotes[t.Link.Note] = true
+	}
+	for _, ch := range g.Characters {
+		note := prefix + noteName(ch.Name)
+		if threadNotes[note] {
+			// Links go by note name, so it must differ from the thread's
+			note += " (Character)"
+		}
+		c.Characters = append(c.Characters, &vaultItem{
+			ID: ch.ID, Kind: "Character", Name: ch.Name, Description: ch.Description, Notes: ch.Notes, Weight: ch.Weight, Status: ch.Status,
+			Link: vaultLink{Note: note, Text: ch.Name},
+		})
+	}
+
+	for _, s := range g.Scenes {
+		text := fmt.Sprintf("Scene %d", s.Number)
+		if s.ExpectedConcept != "" {
+			text += ": " + s.ExpectedConcept
+		}
+		vs := &vaultScene{Scene: s, Link: vaultLink{Note: fmt.Sprintf("%sScene %d", prefix, s.Number), Text: text}}
+		var b strings.Builder
+		for _, e := range s.Entries {
+			b.WriteString(e.Msg)
+			b.WriteByte('\n')
+		}
+		b.WriteString(s.ExpectedConcept + "\n" + s.Summary)
+		mentions := strings.ToLower(b.String())
+		for _, it := range c.Threads {
+			if strings.Contains(mentions, strings.ToLower(it.Name)) {
+				vs.Threads = append(vs.Threads, it.Link)
+				it.Scenes = append(it.Scenes, vs.Link)
+			}
+		}
+		for _, it := range c.Characters {
+			if strings.Contains(mentions, strings.ToLower(it.Name)) {
+				vs.Characters = append(vs.Characters, it.Link)
+				it.Scenes = append(it.Scenes, vs.Link)
+			}
+		}
+		c.Scenes = append(c.Scenes, vs)
+	}
+	for i, s := range c.Scenes {
+		if i > 0 {
+			s.Prev = &c.Scenes[i-1].Link
+		}
+		if i < len(c.Scenes)-1 {
+			s.Next = &c.Scenes[i+1].Link
+		}
+	}
+
+	for _, e := range g.Log {
+		if e.Scene == 0 && e.Type != gdb.LogTypeSceneStart && e.Type != gdb.LogTypeSceneEnd {
+			c.Loose = append(c.Loose, e)
+		}
+	}
+	return c
+}
+
+// noteNameReplacer removes characters that cannot be used in note names and links.
+var noteNameReplacer = strings.NewReplacer("#", "", "^", "", "[", "", "]", "", "|", "-", "%", "")
+
+// noteName turns a name into a note name that is also a valid file name.
+func noteName(name string) string {
+	return strings.TrimSpace(noteNameReplacer.Replace(storage.SanitizeFilename(name)))
+}
+
+// themeNames returns the story themes that are set.
+func themeNames(g *Game) []string {
+	names := []string{}
+	for _, t := range g.StoryThemes {
+		if t != "" {
+			names = append(names, string(t))
+		}
+	}
+	return names
+}
+
+// yamlValue encodes a frontmatter value. JSON is valid YAML; times are written in
+// RFC 3339 and missing values as null.
+func yamlValue(v any) string {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "null"
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// mythicIDPattern finds the mythic_id property in a note's frontmatter.
+var mythicIDPattern = regexp.MustCompile(`(?m)^mythic_id:\s*"?([0-9a-fA-F-]{36})"?\s*$`)
+
+// findVaultNotes returns the notes in a campaign folder by their mythic_id.
+func findVaultNotes(dir string) (map[uuid.UUID]string, error) {
+	found := map[uuid.UUID]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		front, _ := splitFrontmatter(string(data))
+		if m := mythicIDPattern.FindStringSubmatch(front); m != nil {
+			if id, err := uuid.Parse(m[1]); err == nil {
+				found[id] = path
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault notes: %w", err)
+	}
+	return found, nil
+}
+
+// writeVaultNote creates a note or merges it into the existing one at old, moving
+// that note if its name changed.
+func writeVaultNote(res *VaultResult, old string, n vaultNote) error {
+	path := filepath.Join(res.Dir, n.path)
+	if old == "" {
+		old = path
+	}
+	data, err := os.ReadFile(old)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read note %s: %w", old, err)
+	}
+	exists := err == nil
+
+	content := mergeVaultNote(string(data), n)
+	if exists && old == path && content == string(data) {
+		res.Unchanged++
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create vault folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write note %s: %w", path, err)
+	}
+	if old != path && exists {
+		if err := os.Remove(old); err != nil {
+			return fmt.Errorf("failed to move note %s: %w", old, err)
+		}
+	}
+	if exists {
+		res.Updated++
+	} else {
+		res.Created++
+	}
+	return nil
+}
+
+// mergeVaultNote returns the note with the generated frontmatter properties and text
+// of n, keeping everything else of the existing note. A new note gets a Notes
+// section for the user's own text.
+func mergeVaultNote(existing string, n vaultNote) string {
+	front, body := splitFrontmatter(existing)
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	managed := map[string]bool{}
+	for _, f := range n.fields {
+		managed[f.key] = true
+		value := f.value
+		if vaultLists[f.key] {
+			value = mergeYAMLList(value, frontmatterList(front, f.key))
+		}
+		fmt.Fprintf(&b, "%s: %s\n", f.key, value)
+	}
+
+	// Keep the user's own properties, each with its indented continuation lines
+	keep := false
+	for _, line := range strings.Split(front, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' && line[0] != '-' {
+			key, _, _ := strings.Cut(line, ":")
+			keep = !managed[strings.TrimSpace(key)]
+		}
+		if keep {
+			b.WriteString(line + "\n")
+		}
+	}
+	b.WriteString("---\n")
+
+	block := vaultBegin + "\n" + n.text + "\n" + vaultEnd
+	begin, end := strings.Index(body, vaultBegin), strings.Index(body, vaultEnd)
+	switch {
+	case existing == "":
+		b.WriteString(block + "\n\n## Notes\n\n")
+	case begin >= 0 && end > begin:
+		b.WriteString(body[:begin] + block + body[end+len(vaultEnd):])
+	default:
+		b.WriteString(block + "\n\n" + strings.TrimLeft(body, "\n"))
+	}
+	return b.String()
+}
+
+// mergeYAMLList adds the entries of extra that are missing from the generated list
+// value, keeping the generated entries first.
+func mergeYAMLList(value string, extra []string) string {
+	var list []string
+	if err := json.Unmarshal([]byte(value), &list); err != nil || len(extra) == 0 {
+		return value
+	}
+	for _, e := range extra {
+		if !slices.Contains(list, e) {
+			list = append(list, e)
+		}
+	}
+	return yamlValue(list)
+}
+
+// frontmatterList returns the entries of a list property in frontmatter. The list
+// may be written inline, as in [a, "b"], or as a block of "- a" lines, as Obsidian
+// does; a single value counts as a list of one.
+func frontmatterList(front, key string) []string {
+	var list []string
+	in := false
+	for _, line := range strings.Split(front, "\n") {
+		if in {
+			item, ok := strings.CutPrefix(strings.TrimSpace(line), "- ")
+			if !ok {
+				break
+			}
+			list = append(list, yamlScalar(item))
+			continue
+		}
+		k, v, found := strings.Cut(line, ":")
+		if !found || line[0] == ' ' || line[0] == '\t' || strings.TrimSpace(k) != key {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch {
+		case v == "":
+			in = true
+		case strings.HasPrefix(v, "["):
+			if err := json.Unmarshal([]byte(v), &list); err == nil {
+				return list
+			}
+			for _, item := range splitFlowList(strings.Trim(v, "[]")) {
+				if item = yamlScalar(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			return list
+		default:
+			return []string{yamlScalar(v)}
+		}
+	}
+	return list
+}
+
+// splitFlowList splits the entries of an inline YAML list at the commas outside quotes.
+func splitFlowList(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote == '"' && c == '\\':
+			i++ // Skip the escaped character
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+// yamlScalar returns the text of a plain, 'single' or "double" quoted YAML scalar.
+func yamlScalar(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		var v string
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
+
+// splitFrontmatter splits a note into its frontmatter, without the --- lines, and body.
+func splitFrontmatter(note string) (front, body string) {
+	if !strings.HasPrefix(note, "---\n") {
+		return "", note
+	}
+	rest := note[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") {
+		return "", rest[len("---\n"):]
+	}
+	i := strings.Index(rest, "\n---\n")
+	if i < 0 {
+		return "", note
+	}
+	return rest[:i+1], rest[i+len("\n---\n"):]
+}
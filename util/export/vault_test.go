@@ -0,0 +1,68 @@
+package export
+
+import "testing"
+
+func TestFrontmatterList(t *testing.T) {
+	tests := []struct {
+		front string
+		want  []string
+	}{
+		{"title: Scene\ntags: [\"mythic\", \"scene\"]\n", []string{"mythic", "scene"}},
+		{"tags: [mythic, 'it''s', \"a, b\"]\n", []string{"mythic", "it's", "a, b"}},
+		{`tags: [mythic, "say \"hi, there\""]` + "\n", []string{"mythic", `say "hi, there"`}},
+		{"tags:\n  - mythic\n  - \"a, b\"\n- mine\nstatus: open\n", []string{"mythic", "a, b", "mine"}},
+		{"tags: mine\n", []string{"mine"}},
+		{"title: Scene\n  tags: [nested]\n", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got := frontmatterList(tt.front, "tags")
+		if len(got) != len(tt.want) {
+			t.Errorf("frontmatterList(%q) = %q, want %q", tt.front, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("frontmatterList(%q) = %q, want %q", tt.front, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMergeVaultNote(t *testing.T) {
+	n := vaultNote{
+		fields: []vaultField{
+			{"mythic_id", `"1"`},
+			{"title", `"Scene 2"`},
+			{"tags", `["mythic","scene"]`},
+			{"aliases", `["Scene 2"]`},
+		},
+		text: "# Scene 2",
+	}
+
+	created := mergeVaultNote("", n)
+	want := "---\nmythic_id: \"1\"\ntitle: \"Scene 2\"\ntags: [\"mythic\",\"scene\"]\naliases: [\"Scene 2\"]\n---\n" +
+		vaultBegin + "\n# Scene 2\n" + vaultEnd + "\n\n## Notes\n\n"
+	if created != want {
+		t.Fatalf("new note = %q, want %q", created, want)
+	}
+
+	// The user added tags in block style, an alias, a property and notes
+	edited := "---\nmythic_id: \"1\"\ntitle: \"Scene 1\"\ntags:\n  - mythic\n  - scene\n  - \"ambush, night\"\n" +
+		"aliases: [Scene 1, The Ambush]\nmood: tense\n---\n" +
+		vaultBegin + "\n# Scene 1\n" + vaultEnd + "\n\n## Notes\n\nThey never saw it coming.\n"
+	n.text = "# Scene 2 (renamed)"
+	got := mergeVaultNote(edited, n)
+	want = "---\nmythic_id: \"1\"\ntitle: \"Scene 2\"\ntags: [\"mythic\",\"scene\",\"ambush, night\"]\n" +
+		"aliases: [\"Scene 2\",\"Scene 1\",\"The Ambush\"]\nmood: tense\n---\n" +
+		vaultBegin + "\n# Scene 2 (renamed)\n" + vaultEnd + "\n\n## Notes\n\nThey never saw it coming.\n"
+	if got != want {
+		t.Errorf("updated note = %q, want %q", got, want)
+	}
+
+	// Writing the note again changes nothing
+	if again := mergeVaultNote(got, n); again != got {
+		t.Errorf("rewritten note = %q, want %q", again, got)
+	}
+}
@@ -0,0 +1,424 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mge/storage"
+	"github.com/DMXMax/mge/util/theme"
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Identification of exported game documents
+const (
+	ArchiveFormat  = "mythic-cli-game" // Value of the format field
+	ArchiveVersion = 1                 // Current version of the document layout
+)
+
+// Archive is a complete, self-contained copy of a game that can be written as JSON
+// and imported into another database. IDs are kept so entries can refer to their
+// scene and parent entry; they are replaced by new ones on import.
+type Archive struct {
+	Format     string            `json:"format"`
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Game       ArchiveGame       `json:"game"`
+	Scenes     []ArchiveScene    `json:"scenes"`
+	Threads    []ArchiveListItem `json:"threads"`
+	Characters []ArchiveListItem `json:"characters"`
+	Log        []ArchiveLogEntry `json:"log"`
+}
+
+// ArchiveGame holds the game's own settings.
+type ArchiveGame struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Chaos       int          `json:"chaos"` // User-facing chaos factor (1-9)
+	StoryThemes theme.Themes `json:"story_themes"`
+	Oracle      string       `json:"oracle,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ArchiveScene holds a scene and its details.
+type ArchiveScene struct {
+	ID              uuid.UUID `json:"id"`
+	Number          int       `json:"number"`
+	Type            string    `json:"type"`
+	ExpectedConcept string    `json:"expected_concept"`
+	ChaosDieRoll    int       `json:"chaos_die_roll"`
+	Active          bool      `json:"active"`
+	Adjustment      string    `json:"adjustment,omitempty"`
+	Event           string    `json:"event,omitempty"`
+	Summary         string    `json:"summary,omitempty"`
+	PCInControl     *bool     `json:"pc_in_control,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ArchiveListItem holds a thread or character.
+type ArchiveListItem struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Weight      int       `json:"weight"`
+	Status      string    `json:"status"`
+	Notes       string    `json:"notes,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ArchiveLogEntry holds a log entry, the scene and entry it is linked to, and its roll.
+type ArchiveLogEntry struct {
+	ID        uuid.UUID    `json:"id"`
+	Type      int          `json:"type"`
+	Message   string       `json:"message"`
+	SceneID   *uuid.UUID   `json:"scene_id,omitempty"`
+	ParentID  *uuid.UUID   `json:"parent_id,omitempty"`
+	Roll      *ArchiveRoll `json:"roll,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// ArchiveRoll holds the structured result of a roll.
+type ArchiveRoll struct {
+	Kind         string `json:"kind"`
+	Question     string `json:"question"`
+	Oracle       string `json:"oracle,omitempty"`
+	Odds         string `json:"odds,omitempty"`
+	Chaos        int    `json:"chaos,omitempty"`
+	Dice         []int  `json:"dice"`
+	Modifier     int    `json:"modifier"`
+	Total        int    `json:"total"`
+	Target       int    `json:"target"`
+	Answer       string `json:"answer,omitempty"`
+	RandomEvent  bool   `json:"random_event"`
+	Skill        *int   `json:"skill,omitempty"`
+	Difficulty   *int   `json:"difficulty,omitempty"`
+	OpponentDice []int  `json:"opponent_dice,omitempty"`
+}
+
+// ExportGame collects everything belonging to a game into an archive.
+func ExportGame(g *Game) (*Archive, error) {
+	oracleName, err := gameOracleSetting(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	a := &Archive{
+		Format:     ArchiveFormat,
+		Version:    ArchiveVersion,
+		ExportedAt: time.Now(),
+		Game: ArchiveGame{
+			ID:          g.ID,
+			Name:        g.Name,
+			Chaos:       chart.ChaosInternalToUser(int(g.Chaos)),
+			StoryThemes: g.StoryThemes,
+			Oracle:      oracleName,
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		},
+		Scenes:     []ArchiveScene{},
+		Threads:    []ArchiveListItem{},
+		Characters: []ArchiveListItem{},
+		Log:        []ArchiveLogEntry{},
+	}
+
+	scenes, err := GetScenes(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range scenes {
+		a.Scenes = append(a.Scenes, ArchiveScene{
+			ID:              s.ID,
+			Number:          s.Detail.Number,
+			Type:            s.Type,
+			ExpectedConcept: s.ExpectedConcept,
+			ChaosDieRoll:    s.ChaosDieRoll,
+			Active:          s.IsActive,
+			Adjustment:      s.Detail.Adjustment,
+			Event:           s.Detail.Event,
+			Summary:         s.Detail.Summary,
+			PCInControl:     s.Detail.PCInControl,
+			CreatedAt:       s.CreatedAt,
+			UpdatedAt:       s.UpdatedAt,
+		})
+	}
+
+	threads, err := GetThreads(g.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load threads: %w", err)
+	}
+	for _, t := range threads {
+		a.Threads = append(a.Threads, ArchiveListItem{
+			ID: t.ID, Name: t.Name, Description: t.Description, Weight: t.Weight, Status: t.Status,
+			CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt,
+		})
+	}
+	characters, err := GetCharacters(g.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load characters: %w", err)
+	}
+	for _, c := range characters {
+		a.Characters = append(a.Characters, ArchiveListItem{
+			ID: c.ID, Name: c.Name, Description: c.Description, Weight: c.Weight, Status: c.Status, Notes: c.Notes,
+			CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	var entries []LogEntry
+	if err := db.GamesDB.Where("game_id = ?", g.ID).Order("created_at ASC").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load log entries: %w", err)
+	}
+	ids := make([]uuid.UUID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	details, err := GetLogEntryDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+	rolls, err := GetRollDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		ae := ArchiveLogEntry{ID: e.ID, Type: e.Type, Message: e.Msg, CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt}
+		if d, ok := details[e.ID]; ok {
+			ae.SceneID = d.SceneID
+			ae.ParentID = d.ParentID
+		}
+		if r, ok := rolls[e.ID]; ok {
+			ae.Roll = archiveRoll(&r)
+		}
+		a.Log = append(a.Log, ae)
+	}
+	return a, nil
+}
+
+// archiveRoll converts a roll to its archived form, with the odds given by name.
+func archiveRoll(r *RollDetail) *ArchiveRoll {
+	ar := &ArchiveRoll{
+		Kind: r.Kind, Question: r.Question, Oracle: r.Oracle, Chaos: r.Chaos,
+		Dice: r.Dice, Modifier: r.Modifier, Total: r.Total, Target: r.Target, Answer: r.Answer,
+		RandomEvent: r.RandomEvent, Skill: r.Skill, Difficulty: r.Difficulty, OpponentDice: r.OpponentDice,
+	}
+	if r.Kind == RollKindOracle {
+		ar.Odds = chart.Odds(r.Odds).String()
+	}
+	return ar
+}
+
+// rollDetail converts an archived roll back to a roll.
+func (ar *ArchiveRoll) rollDetail() (*RollDetail, error) {
+	r := &RollDetail{
+		Kind: ar.Kind, Question: ar.Question, Oracle: ar.Oracle, Chaos: ar.Chaos,
+		Dice: ar.Dice, Modifier: ar.Modifier, Total: ar.Total, Target: ar.Target, Answer: ar.Answer,
+		RandomEvent: ar.RandomEvent, Skill: ar.Skill, Difficulty: ar.Difficulty, OpponentDice: ar.OpponentDice,
+	}
+	if ar.Odds != "" {
+		odds := chart.MatchOddsPrefix(ar.Odds)
+		if len(odds) != 1 || odds[0].String() != ar.Odds {
+			return nil, fmt.Errorf("unknown odds %q", ar.Odds)
+		}
+		r.Odds = int(odds[0])
+	}
+	return r, nil
+}
+
+// Write encodes the archive as indented JSON.
+func (a *Archive) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(a); err != nil {
+		return fmt.Errorf("failed to encode game: %w", err)
+	}
+	return nil
+}
+
+// ReadArchive decodes an exported game and checks that it is a version this
+// program understands.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return nil, fmt.Errorf("failed to read exported game: %w", err)
+	}
+	if a.Format != ArchiveFormat {
+		return nil, fmt.Errorf("not an exported game (format %q, expected %q)", a.Format, ArchiveFormat)
+	}
+	if a.Version < 1 || a.Version > ArchiveVersion {
+		return nil, fmt.Errorf("unsupported export version %d (this version of mythic-cli reads up to %d)", a.Version, ArchiveVersion)
+	}
+	return &a, nil
+}
+
+// UniqueGameName returns name, or if a game of that name exists (including removed
+// games, which still hold their name), the first free name of the form "name 2", "name 3", ...
+// The name is shortened where needed so that the number fits within the maximum length.
+func UniqueGameName(name string) (string, error) {
+	candidate := name
+	for n := 2; ; n++ {
+		var count int64
+		if err := db.GamesDB.Unscoped().Model(&Game{}).Where("name = ?", candidate).Count(&count).Error; err != nil {
+			return "", fmt.Errorf("failed to check game name: %w", err)
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		suffix := " " + strconv.Itoa(n)
+		candidate = truncateName(name, storage.MaxGameNameLength-len(suffix)) + suffix
+	}
+}
+
+// truncateName shortens name to at most limit bytes without splitting a character,
+// dropping trailing spaces.
+func truncateName(name string, limit int) string {
+	if len(name) > limit {
+		name = name[:limit]
+		for !utf8.ValidString(name) {
+			name = name[:len(name)-1]
+		}
+	}
+	return strings.TrimRight(name, " ")
+}
+
+// ImportGame recreates an archived game under the given name. Everything is created
+// with new IDs, so a game can be imported into the database it was exported from.
+func ImportGame(a *Archive, name string) (*Game, error) {
+	if err := storage.ValidateGameName(name); err != nil {
+		return nil, err
+	}
+	if a.Game.Chaos < chart.MinChaosUser || a.Game.Chaos > chart.MaxChaosUser {
+		return nil, fmt.Errorf("invalid chaos factor %d in exported game", a.Game.Chaos)
+	}
+	oracleName := ""
+	if a.Game.Oracle != "" {
+		o, err := oracle.Find(a.Game.Oracle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oracle in exported game: %w", err)
+		}
+		oracleName = o.Name()
+	}
+
+	g := &Game{
+		Name:        name,
+		Chaos:       int8(chart.ChaosUserToInternal(a.Game.Chaos)),
+		StoryThemes: a.Game.StoryThemes,
+		CreatedAt:   a.Game.CreatedAt,
+		UpdatedAt:   a.Game.UpdatedAt,
+	}
+	err := db.GamesDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(g).Error; err != nil {
+			return fmt.Errorf("failed to create game '%s': %w", name, err)
+		}
+		if oracleName != "" {
+			if err := tx.Create(&GameDetail{GameID: g.ID, Oracle: oracleName}).Error; err != nil {
+				return fmt.Errorf("failed to save game settings: %w", err)
+			}
+		}
+
+		// Old IDs are mapped to the new ones so links between records survive
+		sceneIDs := make(map[uuid.UUID]uuid.UUID, len(a.Scenes))
+		for _, as := range a.Scenes {
+			s := Scene{
+				GameID:          g.ID,
+				Type:            as.Type,
+				ExpectedConcept: as.ExpectedConcept,
+				ChaosDieRoll:    as.ChaosDieRoll,
+				IsActive:        as.Active,
+				CreatedAt:       as.CreatedAt,
+				UpdatedAt:       as.UpdatedAt,
+			}
+			if err := tx.Create(&s).Error; err != nil {
+				return fmt.Errorf("failed to create scene: %w", err)
+			}
+			// IsActive defaults to true, so an inactive scene must be written explicitly
+			if !as.Active {
+				if err := tx.Model(&s).UpdateColumn("is_active", false).Error; err != nil {
+					return fmt.Errorf("failed to create scene: %w", err)
+				}
+			}
+			sceneIDs[as.ID] = s.ID
+			detail := SceneDetail{
+				SceneID:     s.ID,
+				GameID:      g.ID,
+				Number:      as.Number,
+				Adjustment:  as.Adjustment,
+				Event:       as.Event,
+				Summary:     as.Summary,
+				PCInControl: as.PCInControl,
+			}
+			if err := tx.Create(&detail).Error; err != nil {
+				return fmt.Errorf("failed to create scene details: %w", err)
+			}
+		}
+
+		for _, at := range a.Threads {
+			t := Thread{GameID: g.ID, Name: at.Name, Description: at.Description, Weight: at.Weight, Status: at.Status,
+				CreatedAt: at.CreatedAt, UpdatedAt: at.UpdatedAt}
+			if err := tx.Create(&t).Error; err != nil {
+				return fmt.Errorf("failed to create thread '%s': %w", at.Name, err)
+			}
+		}
+		for _, ac := range a.Characters {
+			c := Character{GameID: g.ID, Name: ac.Name, Description: ac.Description, Weight: ac.Weight, Status: ac.Status,
+				Notes: ac.Notes, CreatedAt: ac.CreatedAt, UpdatedAt: ac.UpdatedAt}
+			if err := tx.Create(&c).Error; err != nil {
+				return fmt.Errorf("failed to create character '%s': %w", ac.Name, err)
+			}
+		}
+
+		// Entries are created oldest first, so a parent exists before the entries it triggered
+		entryIDs := make(map[uuid.UUID]uuid.UUID, len(a.Log))
+		for _, ae := range a.Log {
+			e := LogEntry{GameID: g.ID, Type: ae.Type, Msg: ae.Message, CreatedAt: ae.CreatedAt, UpdatedAt: ae.UpdatedAt}
+			if err := tx.Create(&e).Error; err != nil {
+				return fmt.Errorf("failed to create log entry: %w", err)
+			}
+			entryIDs[ae.ID] = e.ID
+
+			detail := LogEntryDetail{LogEntryID: e.ID, GameID: g.ID}
+			if ae.SceneID != nil {
+				if id, ok := sceneIDs[*ae.SceneID]; ok {
+					detail.SceneID = &id
+				}
+			}
+			if ae.ParentID != nil {
+				if id, ok := entryIDs[*ae.ParentID]; ok {
+					detail.ParentID = &id
+				}
+			}
+			if detail.SceneID != nil || detail.ParentID != nil {
+				if err := tx.Create(&detail).Error; err != nil {
+					return fmt.Errorf("failed to create log entry details: %w", err)
+				}
+			}
+
+			if ae.Roll != nil {
+				roll, err := ae.Roll.rollDetail()
+				if err != nil {
+					return fmt.Errorf("log entry %s: %w", ae.ID, err)
+				}
+				roll.LogEntryID = e.ID
+				roll.GameID = g.ID
+				if err := tx.Create(roll).Error; err != nil {
+					return fmt.Errorf("failed to create roll details: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,25 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/DMXMax/mge/storage"
+)
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  string
+	}{
+		{"Kat in Shadow", 30, "Kat in Shadow"},
+		{"Abcdefghij Klmnopqrst Uvwxyzabcd", storage.MaxGameNameLength - 2, "Abcdefghij Klmnopqrst Uvwxyzab"},
+		{"Abcdefghij Klmnopqrst Uvwxyz abc", storage.MaxGameNameLength - 3, "Abcdefghij Klmnopqrst Uvwxyz"},
+		{"Café au lait", 4, "Caf"},
+	}
+	for _, tt := range tests {
+		if got := truncateName(tt.name, tt.limit); got != tt.want {
+			t.Errorf("truncateName(%q, %d) = %q, want %q", tt.name, tt.limit, got, tt.want)
+		}
+	}
+}
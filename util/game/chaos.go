@@ -0,0 +1,16 @@
+package game
+
+import (
+	"github.com/DMXMax/mythic-cli/util/db"
+)
+
+// SaveChaos sets the chaos factor of a game (internal range 0-8) and persists it.
+// Only the chaos field is written, so associations such as the log are never re-saved.
+func SaveChaos(g *Game, chaos int8) error {
+	g.SetChaos(chaos)
+	// Use Select() to only update chaos field, avoiding association saves
+	// This prevents duplicate log entries if Log field is populated
+	return db.GamesDB.Model(g).Select("chaos", "updated_at").Updates(map[string]interface{}{
+		"chaos": g.Chaos,
+	}).Error
+}
@@ -0,0 +1,37 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/DMXMax/mge/storage"
+	"github.com/DMXMax/mythic-cli/util/db"
+)
+
+// OpenDatabase connects to the SQLite database at path, creating it if needed, and
+// migrates it. It replaces the global connection and clears the current game, which
+// belongs to the previous database.
+func OpenDatabase(path string) error {
+	conn, err := storage.InitDatabase(path)
+	if err != nil {
+		return fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+
+	// Migrate the shared models first (including Thread/Character/Scene)
+	if err := conn.AutoMigrate(&storage.Game{}, &storage.LogEntry{}, &storage.Thread{}, &storage.Character{}, &storage.Scene{}); err != nil {
+		return fmt.Errorf("failed to migrate database models: %w", err)
+	}
+	// Then the CLI-specific tables and one-time data migrations
+	if err := Migrate(conn); err != nil {
+		return fmt.Errorf("failed to migrate CLI data: %w", err)
+	}
+
+	if old := db.GamesDB; old != nil {
+		if sqlDB, err := old.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}
+	db.GamesDB = conn
+	db.Path = path
+	Current = nil
+	return nil
+}
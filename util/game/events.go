@@ -0,0 +1,61 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/DMXMax/mge/util"
+	"github.com/google/uuid"
+)
+
+// Fallback targets used when the list an event focus rolls on is empty
+const (
+	NewNPCTarget    = "new NPC"
+	NewThreadTarget = "new thread"
+)
+
+// ResolvedEvent is a random event whose focus has been resolved against a game's
+// Threads and Characters Lists, so that it names the thread or character involved.
+type ResolvedEvent struct {
+	*util.Event
+	Target string // Thread or character the event is about ("" if the focus has none)
+}
+
+// String returns the event with its resolved target, e.g.
+// "NPC Action (Old Tom): Trick Tactics (Helpfully Comforting, Hinder Disadvantage)".
+func (e ResolvedEvent) String() string {
+	focus := util.EventText[e.Focus]
+	if e.Target != "" {
+		focus = fmt.Sprintf("%s (%s)", focus, e.Target)
+	}
+	return fmt.Sprintf("%s: %s %s (%s %s, %s %s)", focus, e.Action, e.Subject,
+		e.Meaning.Descriptors[0], e.Meaning.Descriptors[1], e.Meaning.Actions[0], e.Meaning.Actions[1])
+}
+
+// GetEvent generates a random event for a game and resolves its focus: NPC events
+// roll on the Characters List and thread events on the Threads List, falling back
+// to a new NPC or thread when the list is empty.
+func GetEvent(gameID uuid.UUID) (*ResolvedEvent, error) {
+	e := &ResolvedEvent{Event: util.GetEvent()}
+
+	switch e.Focus {
+	case util.NPCAction, util.NPCNegative, util.NPCPositive:
+		c, err := RollCharacter(gameID)
+		if err != nil {
+			return nil, err
+		}
+		e.Target = NewNPCTarget
+		if c != nil {
+			e.Target = c.Name
+		}
+	case util.MoveTowardThread, util.MoveAwayFromThread, util.CloseThread:
+		t, err := RollThread(gameID)
+		if err != nil {
+			return nil, err
+		}
+		e.Target = NewThreadTarget
+		if t != nil {
+			e.Target = t.Name
+		}
+	}
+	return e, nil
+}
@@ -12,12 +12,27 @@ const (
 	LogTypeDiceRoll   = 1 // Dice roll entries
 	LogTypeSceneStart = 2 // Scene start marker
 	LogTypeSceneEnd   = 3 // Scene end marker
+	LogTypeEvent      = 4 // Random event entries
+	LogTypeList       = 5 // Changes to the Threads and Characters Lists
 )
 
+// LogTypeNames names the log entry types, as used in JSON output and export templates.
+var LogTypeNames = map[int]string{
+	LogTypeStory:      "story",
+	LogTypeDiceRoll:   "roll",
+	LogTypeSceneStart: "scene_start",
+	LogTypeSceneEnd:   "scene_end",
+	LogTypeEvent:      "event",
+	LogTypeList:       "list",
+}
+
 // Re-export types from storage package for convenience
 type (
-	Game     = storage.Game
-	LogEntry = storage.LogEntry
+	Game      = storage.Game
+	LogEntry  = storage.LogEntry
+	Thread    = storage.Thread
+	Character = storage.Character
+	Scene     = storage.Scene
 )
 
 // Current is the currently active game session.
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/google/uuid"
+)
+
+// Thread status values
+const (
+	ThreadActive   = "active"
+	ThreadResolved = "resolved"
+)
+
+// Character status values
+const (
+	CharacterActive   = "active"
+	CharacterInactive = "inactive"
+)
+
+// MaxListWeight is the maximum number of times an item may appear on a Mythic list.
+const MaxListWeight = 3
+
+// GetThreads returns the threads of a game in the order they were added.
+// Resolved threads are only included if all is true.
+func GetThreads(gameID uuid.UUID, all bool) ([]Thread, error) {
+	var threads []Thread
+	q := db.GamesDB.Where("game_id = ?", gameID)
+	if !all {
+		q = q.Where("status = ?", ThreadActive)
+	}
+	if err := q.Order("created_at ASC").Find(&threads).Error; err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+// FindThread looks up a thread of a game by its list number (as shown by
+// `thread list --all`) or by name. Names are matched case-insensitively,
+// first exactly and then by unique prefix.
+func FindThread(gameID uuid.UUID, ref string) (*Thread, error) {
+	threads, err := GetThreads(gameID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load threads: %w", err)
+	}
+	i, err := findByRef(threads, ref, func(t Thread) string { return t.Name })
+	if err != nil {
+		return nil, fmt.Errorf("thread %w", err)
+	}
+	return &threads[i], nil
+}
+
+// AddThread adds a new active thread to a game's Threads List.
+func AddThread(gameID uuid.UUID, name, description string, weight int) (*Thread, error) {
+	if weight < 1 || weight > MaxListWeight {
+		return nil, fmt.Errorf("weight must be between 1 and %d", MaxListWeight)
+	}
+	thread := Thread{
+		GameID:      gameID,
+		Name:        name,
+		Description: description,
+		Weight:      weight,
+		Status:      ThreadActive,
+	}
+	if err := db.GamesDB.Create(&thread).Error; err != nil {
+		return nil, fmt.Errorf("failed to add thread: %w", err)
+	}
+	return &thread, nil
+}
+
+// SetThreadStatus changes the status of a thread (e.g., ThreadResolved).
+func SetThreadStatus(t *Thread, status string) error {
+	if t.Status == status {
+		return fmt.Errorf("thread '%s' is already %s", t.Name, status)
+	}
+	if err := db.GamesDB.Model(t).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update thread: %w", err)
+	}
+	return nil
+}
+
+// RollThread picks a random active thread of a game, honouring weights.
+// It returns nil if the Threads List is empty.
+func RollThread(gameID uuid.UUID) (*Thread, error) {
+	threads, err := GetThreads(gameID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load threads: %w", err)
+	}
+	i := pickWeighted(threads, func(t Thread) int { return t.Weight })
+	if i < 0 {
+		return nil, nil
+	}
+	return &threads[i], nil
+}
+
+// GetCharacters returns the characters of a game in the order they were added.
+// Retired (inactive) characters are only included if all is true.
+func GetCharacters(gameID uuid.UUID, all bool) ([]Character, error) {
+	var characters []Character
+	q := db.GamesDB.Where("game_id = ?", gameID)
+	if !all {
+		q = q.Where("status = ?", CharacterActive)
+	}
+	if err := q.Order("created_at ASC").Find(&characters).Error; err != nil {
+		return nil, err
+	}
+	return characters, nil
+}
+
+// FindCharacter looks up a character of a game by its list number (as shown by
+// `character list --all`) or by name, using the same matching rules as FindThread.
+func FindCharacter(gameID uuid.UUID, ref string) (*Character, error) {
+	characters, err := GetCharacters(gameID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load characters: %w", err)
+	}
+	i, err := findByRef(characters, ref, func(c Character) string { return c.Name })
+	if err != nil {
+		return nil, fmt.Errorf("character %w", err)
+	}
+	return &characters[i], nil
+}
+
+// AddCharacter adds a new active character to a game's Characters List.
+func AddCharacter(gameID uuid.UUID, name, description string, weight int) (*Character, error) {
+	if weight < 1 || weight > MaxListWeight {
+		return nil, fmt.Errorf("weight must be between 1 and %d", MaxListWeight)
+	}
+	character := Character{
+		GameID:      gameID,
+		Name:        name,
+		Description: description,
+		Weight:      weight,
+		Status:      CharacterActive,
+	}
+	if err := db.GamesDB.Create(&character).Error; err != nil {
+		return nil, fmt.Errorf("failed to add character: %w", err)
+	}
+	return &character, nil
+}
+
+// SetCharacterStatus changes the status of a character (e.g., CharacterInactive).
+func SetCharacterStatus(c *Character, status string) error {
+	if c.Status == status {
+		return fmt.Errorf("character '%s' is already %s", c.Name, status)
+	}
+	if err := db.GamesDB.Model(c).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update character: %w", err)
+	}
+	return nil
+}
+
+// RollCharacter picks a random active character of a game, honouring weights:
+// a character with weight 2 occupies two entries on the list.
+// It returns nil if the Characters List is empty.
+func RollCharacter(gameID uuid.UUID) (*Character, error) {
+	characters, err := GetCharacters(gameID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load characters: %w", err)
+	}
+	i := pickWeighted(characters, func(c Character) int { return c.Weight })
+	if i < 0 {
+		return nil, nil
+	}
+	return &characters[i], nil
+}
+
+// pickWeighted returns the index of a random item, where each item occupies
+// weight(item) entries on the list. It returns -1 if the list has no entries.
+func pickWeighted[T any](items []T, weight func(T) int) int {
+	total := 0
+	for _, item := range items {
+		total += max(weight(item), 1)
+	}
+	if total == 0 {
+		return -1
+	}
+	roll := rand.IntN(total)
+	for i, item := range items {
+		roll -= max(weight(item), 1)
+		if roll < 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// findByRef resolves ref to an index in items. ref may be a 1-based list number
+// or a name; names are matched case-insensitively, exactly first and then by prefix.
+func findByRef[T any](items []T, ref string, name func(T) string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, fmt.Errorf("name or number required")
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(items) {
+			return -1, fmt.Errorf("number %d is out of range (1-%d)", n, len(items))
+		}
+		return n - 1, nil
+	}
+
+	lower := strings.ToLower(ref)
+	for i, item := range items {
+		if strings.ToLower(name(item)) == lower {
+			return i, nil
+		}
+	}
+	match := -1
+	for i, item := range items {
+		if strings.HasPrefix(strings.ToLower(name(item)), lower) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%q is ambiguous", ref)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("%q not found", ref)
+	}
+	return match, nil
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AddLog creates a new entry in the game's log and persists it immediately.
+// The entry is attached to the game's active scene, if there is one.
+//
+// Parameters:
+//   - g: The game the entry belongs to
+//   - typ: The log entry type (one of the LogType constants)
+//   - msg: The log message
+//
+// Returns the created entry and any error that occurred while saving it.
+func AddLog(g *Game, typ int, msg string) (*LogEntry, error) {
+	scene, err := ActiveScene(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	var sceneID *uuid.UUID
+	if scene != nil {
+		sceneID = &scene.ID
+	}
+	return AddSceneLog(g, sceneID, typ, msg)
+}
+
+// AddSceneLog creates a new entry in the game's log attached to the given scene.
+// A nil sceneID records the entry outside of any scene.
+func AddSceneLog(g *Game, sceneID *uuid.UUID, typ int, msg string) (*LogEntry, error) {
+	return createLog(db.GamesDB, g, LogEntryDetail{SceneID: sceneID}, nil, typ, msg)
+}
+
+// AddLinkedLog creates a new entry in the game's log that was triggered by parent,
+// such as a random event caused by a Fate Chart question. The entry is attached
+// to the same scene as its parent.
+func AddLinkedLog(g *Game, parent *LogEntry, typ int, msg string) (*LogEntry, error) {
+	var parentDetail LogEntryDetail
+	err := db.GamesDB.Where("log_entry_id = ?", parent.ID).First(&parentDetail).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load log entry details: %w", err)
+	}
+	return createLog(db.GamesDB, g, LogEntryDetail{SceneID: parentDetail.SceneID, ParentID: &parent.ID}, nil, typ, msg)
+}
+
+// createLog saves a log entry together with its details and, for dice rolls, the
+// structured roll, using conn, which may be a transaction already in progress. The
+// details are only stored if they link the entry to a scene or to another entry.
+func createLog(conn *gorm.DB, g *Game, detail LogEntryDetail, roll *RollDetail, typ int, msg string) (*LogEntry, error) {
+	entry := LogEntry{Type: typ, Msg: msg, GameID: g.ID}
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+		if roll != nil {
+			roll.LogEntryID = entry.ID
+			roll.GameID = g.ID
+			if err := tx.Create(roll).Error; err != nil {
+				return err
+			}
+		}
+		if detail.SceneID == nil && detail.ParentID == nil {
+			return nil
+		}
+		detail.LogEntryID = entry.ID
+		detail.GameID = g.ID
+		return tx.Create(&detail).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ActiveScene returns the active scene of a game, or nil if there is none.
+func ActiveScene(gameID uuid.UUID) (*Scene, error) {
+	var scene Scene
+	err := db.GamesDB.Where("game_id = ? AND is_active = ?", gameID, true).First(&scene).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active scene: %w", err)
+	}
+	return &scene, nil
+}
+
+// DeleteLogEntries permanently removes log entries together with their details and rolls.
+func DeleteLogEntries(entries []LogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return db.GamesDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("log_entry_id IN ?", ids).Delete(&LogEntryDetail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("log_entry_id IN ?", ids).Delete(&RollDetail{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entries).Error
+	})
+}
+
+// GetLogEntryDetails returns the details of the given log entries keyed by entry ID.
+// Entries without details are missing from the map.
+func GetLogEntryDetails(ids []uuid.UUID) (map[uuid.UUID]LogEntryDetail, error) {
+	details := make(map[uuid.UUID]LogEntryDetail, len(ids))
+	if len(ids) == 0 {
+		return details, nil
+	}
+	var rows []LogEntryDetail
+	if err := db.GamesDB.Where("log_entry_id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load log entry details: %w", err)
+	}
+	for _, d := range rows {
+		details[d.LogEntryID] = d
+	}
+	return details, nil
+}
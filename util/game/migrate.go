@@ -0,0 +1,187 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrations lists the one-time data migrations in the order they must be applied.
+// Each migration runs once per database and is recorded in the migrations table.
+var migrations = []struct {
+	name string
+	run  func(tx *gorm.DB) error
+}{
+	{"attach-log-entries-to-scenes", attachEntriesToScenes},
+	{"classify-scene-markers", classifySceneMarkers},
+	{"parse-roll-messages", parseRollMessages},
+	{"store-scene-events", storeSceneEvents},
+	{"classify-list-entries", classifyListEntries},
+}
+
+// Migrate creates or updates the tables of the CLI-specific models and applies any
+// pending one-time data migrations. The shared storage models must be migrated first.
+func Migrate(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&GameDetail{}, &SceneDetail{}, &LogEntryDetail{}, &Migration{}, &State{}, &RollDetail{}); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		var applied Migration
+		err := tx.Where("name = ?", m.name).First(&applied).Error
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check migration %s: %w", m.name, err)
+		}
+
+		if err := tx.Transaction(func(tx *gorm.DB) error {
+			if err := m.run(tx); err != nil {
+				return err
+			}
+			return tx.Create(&Migration{Name: m.name, AppliedAt: time.Now()}).Error
+		}); err != nil {
+			return fmt.Errorf("migration %s failed: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// attachEntriesToScenes links log entries that were written before entries were
+// attached to scenes. An entry belongs to the latest scene started at or before it.
+func attachEntriesToScenes(tx *gorm.DB) error {
+	var scenes []Scene
+	if err := tx.Order("created_at ASC").Find(&scenes).Error; err != nil {
+		return err
+	}
+	for i, s := range scenes {
+		q := tx.Model(&LogEntry{}).
+			Where("game_id = ? AND created_at >= ?", s.GameID, s.CreatedAt).
+			Where("id NOT IN (?)", tx.Model(&LogEntryDetail{}).Select("log_entry_id"))
+		for _, next := range scenes[i+1:] {
+			if next.GameID == s.GameID {
+				q = q.Where("created_at < ?", next.CreatedAt)
+				break
+			}
+		}
+
+		var entries []LogEntry
+		if err := q.Find(&entries).Error; err != nil {
+			return err
+		}
+		for _, e := range entries {
+			sceneID := s.ID
+			detail := LogEntryDetail{LogEntryID: e.ID, GameID: e.GameID, SceneID: &sceneID}
+			if err := tx.Create(&detail).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// classifySceneMarkers converts scene start and end markers that older versions
+// wrote as story entries (e.g. "--- Scene Start: Expected | concept ---") into
+// LogTypeSceneStart and LogTypeSceneEnd entries, stripping the text decoration.
+func classifySceneMarkers(tx *gorm.DB) error {
+	var entries []LogEntry
+	if err := tx.Where("type = ? AND (msg LIKE ? OR msg LIKE ?)", LogTypeStory, "--- Scene Start%", "--- Scene End%").
+		Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, e := range entries {
+		typ := LogTypeSceneStart
+		msg := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(e.Msg), "---"))
+		if strings.HasPrefix(msg, "--- Scene End") {
+			typ = LogTypeSceneEnd
+			msg = strings.TrimPrefix(msg, "--- Scene End")
+		} else {
+			msg = strings.TrimPrefix(msg, "--- Scene Start")
+			msg = strings.Replace(msg, "| Expected: ", "| ", 1)
+		}
+		msg = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(msg), ":"))
+		if err := tx.Model(&LogEntry{}).Where("id = ?", e.ID).
+			Updates(map[string]interface{}{"type": typ, "msg": msg}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseRollMessages recovers the structured rolls of dice roll entries written before
+// rolls were stored with their entries, by parsing their messages. Messages that are
+// not recognized are left without roll details.
+func parseRollMessages(tx *gorm.DB) error {
+	var entries []LogEntry
+	if err := tx.Where("type = ?", LogTypeDiceRoll).
+		Where("id NOT IN (?)", tx.Model(&RollDetail{}).Select("log_entry_id")).
+		Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, e := range entries {
+		roll := parseRoll(e.Msg)
+		if roll == nil {
+			continue
+		}
+		roll.LogEntryID = e.ID
+		roll.GameID = e.GameID
+		if err := tx.Create(roll).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeSceneEvents copies the Random Event of scenes started before events were kept
+// with their scene out of the scene start entry, e.g. "Interrupt | concept | Event: ...".
+func storeSceneEvents(tx *gorm.DB) error {
+	var entries []LogEntry
+	if err := tx.Where("type = ? AND msg LIKE ?", LogTypeSceneStart, "%| Event: %").Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, e := range entries {
+		var detail LogEntryDetail
+		if err := tx.Where("log_entry_id = ?", e.ID).Limit(1).Find(&detail).Error; err != nil {
+			return err
+		}
+		if detail.SceneID == nil {
+			continue
+		}
+		// Scenes that were never listed have no details yet; NumberScenes numbers them later
+		scene := SceneDetail{SceneID: *detail.SceneID, GameID: e.GameID}
+		if err := tx.FirstOrCreate(&scene, SceneDetail{SceneID: *detail.SceneID}).Error; err != nil {
+			return err
+		}
+		if scene.Event != "" {
+			continue
+		}
+		event := e.Msg[strings.LastIndex(e.Msg, "| Event: ")+len("| Event: "):]
+		if err := tx.Model(&scene).Update("event", strings.TrimSpace(event)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listEntryPrefixes begin the messages the thread and character commands log.
+var listEntryPrefixes = []string{
+	"Thread added: ", "Thread removed: ", "Thread resolved: ", "Thread reopened: ", "Thread weight: ",
+	"Character added: ", "Character removed: ", "Character retired: ", "Character returned: ",
+	"Character weight: ", "Character note: ",
+}
+
+// classifyListEntries converts the changes to the Threads and Characters Lists that
+// older versions logged as story entries (e.g. "Thread added: Find the map") into
+// LogTypeList entries.
+func classifyListEntries(tx *gorm.DB) error {
+	q := tx.Model(&LogEntry{}).Where("type = ?", LogTypeStory)
+	cond := tx.Where("msg LIKE ?", listEntryPrefixes[0]+"%")
+	for _, p := range listEntryPrefixes[1:] {
+		cond = cond.Or("msg LIKE ?", p+"%")
+	}
+	return q.Where(cond).Update("type", LogTypeList).Error
+}
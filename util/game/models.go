@@ -0,0 +1,81 @@
+package game
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameDetail stores CLI-specific settings of a storage.Game that the shared model
+// has no columns for. It is keyed by the game's ID.
+type GameDetail struct {
+	GameID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time // When the detail record was created
+	UpdatedAt time.Time // When the detail record was last updated
+	Oracle    string    // Oracle used for yes/no questions (empty for the default)
+}
+
+// SceneDetail stores CLI-specific information about a storage.Scene that the
+// shared model has no columns for. It is keyed by the scene's ID.
+type SceneDetail struct {
+	SceneID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt   time.Time // When the detail record was created
+	UpdatedAt   time.Time // When the detail record was last updated
+	GameID      uuid.UUID `gorm:"type:uuid;index"` // Foreign key to the game
+	Number      int       // Sequence number of the scene within its game (1-based)
+	Adjustment  string    // Scene Adjustment rolled for an altered scene
+	Event       string    // Random Event that interrupted an interrupt scene
+	Summary     string    // Summary captured at the end of the scene
+	PCInControl *bool     // Whether the PCs were in control (nil until the scene ends)
+}
+
+// LogEntryDetail stores CLI-specific information about a storage.LogEntry that the
+// shared model has no columns for, such as the scene the entry was recorded in.
+// It is keyed by the entry's ID.
+type LogEntryDetail struct {
+	LogEntryID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	GameID     uuid.UUID  `gorm:"type:uuid;index"` // Foreign key to the game
+	SceneID    *uuid.UUID `gorm:"type:uuid;index"` // Scene the entry was recorded in (nil if none)
+	ParentID   *uuid.UUID `gorm:"type:uuid;index"` // Entry that triggered this one, e.g. the question behind a random event
+}
+
+// Migration records a one-time data migration that has been applied to the database.
+type Migration struct {
+	Name      string `gorm:"primaryKey"` // Unique name of the migration
+	AppliedAt time.Time
+}
+
+// State stores CLI state that must survive between invocations, such as the
+// selected game, as name/value pairs.
+type State struct {
+	Name      string `gorm:"primaryKey"`
+	Value     string
+	UpdatedAt time.Time
+}
+
+// Kinds of roll recorded in a RollDetail
+const (
+	RollKindOracle = "oracle" // A yes/no question answered by an oracle
+	RollKindFate   = "fate"   // A 4dF roll
+)
+
+// RollDetail stores the structured result of a roll recorded as a LogTypeDiceRoll
+// entry, from which the entry's message is rendered. It is keyed by the entry's ID.
+type RollDetail struct {
+	LogEntryID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	GameID       uuid.UUID `gorm:"type:uuid;index"` // Foreign key to the game
+	Kind         string    `gorm:"index"`           // RollKindOracle or RollKindFate
+	Question     string    // Question or message the roll was made for
+	Oracle       string    // Oracle that answered the question (empty for 4dF rolls or if unknown)
+	Odds         int       // Odds of the question (a chart.Odds value)
+	Chaos        int       // User-facing chaos factor (1-9) the question was asked with
+	Dice         []int     `gorm:"serializer:json"` // Individual dice rolled
+	Modifier     int       // Modifier added to the dice by the oracle
+	Total        int       // Value the answer was read from; dice plus skill for 4dF rolls
+	Target       int       // Value the total was compared against; the final difficulty for 4dF rolls
+	Answer       string    // Oracle answer or 4dF outcome, e.g. "Exceptional Yes" or "Success"
+	RandomEvent  bool      // Whether the roll triggered a Random Event
+	Skill        *int      // Skill added to a 4dF roll (nil if none)
+	Difficulty   *int      // Difficulty of a 4dF roll, or the opponent's skill if opposed (nil if none)
+	OpponentDice []int     `gorm:"serializer:json"` // Dice of the opponent's 4dF roll (nil unless opposed)
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/DMXMax/mythic-cli/util/config"
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+	"github.com/google/uuid"
+)
+
+// GameOracle returns the name of the oracle selected for a game. If none was chosen,
+// it returns the oracle setting of the config file (oracle.Default unless configured).
+func GameOracle(gameID uuid.UUID) (string, error) {
+	name, err := gameOracleSetting(gameID)
+	if err != nil || name != "" {
+		return name, err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	o, err := oracle.Find(cfg.Value(config.Oracle))
+	if err != nil {
+		return "", fmt.Errorf("setting %s: %w", config.Oracle, err)
+	}
+	return o.Name(), nil
+}
+
+// gameOracleSetting returns the oracle chosen for a game, or "" if none was chosen.
+func gameOracleSetting(gameID uuid.UUID) (string, error) {
+	var detail GameDetail
+	if err := db.GamesDB.Where("game_id = ?", gameID).Limit(1).Find(&detail).Error; err != nil {
+		return "", fmt.Errorf("failed to load game settings: %w", err)
+	}
+	return detail.Oracle, nil
+}
+
+// SetGameOracle persists the oracle used for a game's yes/no questions.
+func SetGameOracle(gameID uuid.UUID, name string) error {
+	detail := GameDetail{GameID: gameID}
+	if err := db.GamesDB.FirstOrCreate(&detail, GameDetail{GameID: gameID}).Error; err != nil {
+		return fmt.Errorf("failed to load game settings: %w", err)
+	}
+	if err := db.GamesDB.Model(&detail).Update("oracle", name).Error; err != nil {
+		return fmt.Errorf("failed to save game settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package game
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/DMXMax/mythic-cli/util/oracle"
+	"github.com/google/uuid"
+)
+
+// NewOracleRoll records the answer an oracle gave to a question.
+func NewOracleRoll(question string, r *oracle.Result) *RollDetail {
+	return &RollDetail{
+		Kind:        RollKindOracle,
+		Question:    question,
+		Oracle:      r.Oracle,
+		Odds:        int(r.Odds),
+		Chaos:       r.Chaos,
+		Dice:        r.Dice,
+		Modifier:    r.Modifier,
+		Total:       r.Total,
+		Target:      r.Target,
+		Answer:      r.Answer,
+		RandomEvent: r.RandomEvent,
+	}
+}
+
+// OracleResult returns the oracle result recorded by an oracle roll.
+func (d *RollDetail) OracleResult() *oracle.Result {
+	return &oracle.Result{
+		Oracle:      d.Oracle,
+		Odds:        chart.Odds(d.Odds),
+		Chaos:       d.Chaos,
+		Dice:        d.Dice,
+		Modifier:    d.Modifier,
+		Total:       d.Total,
+		Target:      d.Target,
+		Answer:      d.Answer,
+		RandomEvent: d.RandomEvent,
+	}
+}
+
+// Shifts returns how far a 4dF total beat (or missed) its difficulty.
+func (d *RollDetail) Shifts() int {
+	return d.Total - d.Target
+}
+
+// String renders the roll as it appears in the game log, e.g.
+// "Is it locked? (C:5) -> likely - 42: Yes" or
+// "Pick the lock | 4dF { 1, 0, -1, 1 } +1; skill 2 -> 3 vs diff 2: Success (+1)".
+func (d *RollDetail) String() string {
+	if d.Kind == RollKindFate {
+		return d.fateString()
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s (C:%d) -> %s", d.Question, d.Chaos, d.OracleResult()))
+}
+
+// fateString renders a 4dF roll.
+func (d *RollDetail) fateString() string {
+	s := fateDiceString(d.Dice)
+	if d.Skill != nil {
+		s = fmt.Sprintf("%s; skill %d -> %d", s, *d.Skill, d.Total)
+	}
+	if d.Difficulty != nil {
+		if d.OpponentDice != nil {
+			opponent := fmt.Sprintf("%s; skill %d -> %d", fateDiceString(d.OpponentDice), *d.Difficulty, d.Target)
+			s = fmt.Sprintf("%s vs Opponent (%s): %s (%+d)", s, opponent, d.Answer, d.Shifts())
+		} else {
+			s = fmt.Sprintf("%s vs diff %d: %s (%+d)", s, *d.Difficulty, d.Answer, d.Shifts())
+		}
+	}
+	if strings.TrimSpace(d.Question) != "" {
+		return fmt.Sprintf("%s | 4dF %s", d.Question, s)
+	}
+	return "4dF " + s
+}
+
+// fateDiceString renders four Fate dice and their sum, e.g. "{ 1, 0, -1, 1 } +1".
+func fateDiceString(dice []int) string {
+	parts := make([]string, len(dice))
+	sum := 0
+	for i, v := range dice {
+		parts[i] = strconv.Itoa(v)
+		sum += v
+	}
+	return fmt.Sprintf("{ %s } %+d", strings.Join(parts, ", "), sum)
+}
+
+// AddRoll records a roll in the game's log. The entry's message is rendered from
+// the roll, which is stored with it, and the entry is attached to the game's active
+// scene, if there is one.
+func AddRoll(g *Game, roll *RollDetail) (*LogEntry, error) {
+	scene, err := ActiveScene(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	detail := LogEntryDetail{}
+	if scene != nil {
+		detail.SceneID = &scene.ID
+	}
+	return createLog(db.GamesDB, g, detail, roll, LogTypeDiceRoll, roll.String())
+}
+
+// GetRollDetails returns the roll details of the given log entries keyed by entry ID.
+// Entries that are not rolls, or whose details could not be recovered, are missing from the map.
+func GetRollDetails(ids []uuid.UUID) (map[uuid.UUID]RollDetail, error) {
+	details := make(map[uuid.UUID]RollDetail, len(ids))
+	if len(ids) == 0 {
+		return details, nil
+	}
+	var rows []RollDetail
+	if err := db.GamesDB.Where("log_entry_id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load roll details: %w", err)
+	}
+	for _, d := range rows {
+		details[d.LogEntryID] = d
+	}
+	return details, nil
+}
+
+var (
+	// oracleRollPattern matches "question (C:5) -> likely - 7+6+1 = 14: Yes", where a
+	// limited total follows the sum as in "6+2 = 8 -> 6". Older Fate Chart entries may
+	// end with "| Event: ..." describing the random event.
+	oracleRollPattern = regexp.MustCompile(`^(?:(.*?) )?\(C:(\d)\) -> ([a-z ]+) - (\d+|[\d+]+[+-]\d+ = -?\d+(?: -> -?\d+)?): (.+?)(?: \| Event: .*)?$`)
+	// fateRollPattern matches "question | 4dF { 1, 0, -1, 1 } +1; skill 2 -> 3 vs diff 2: Success (+1)".
+	fateRollPattern = regexp.MustCompile(`^(?:(.*) \| )?4dF \{ (-?\d), (-?\d), (-?\d), (-?\d) \} [+-]\d+` +
+		`(?:; skill (-?\d+) -> -?\d+)?` +
+		`(?: vs diff (-?\d+): ([A-Za-z ]+) \([+-]\d+\)` +
+		`| vs Opponent \(\{ (-?\d), (-?\d), (-?\d), (-?\d) \} [+-]\d+; skill (-?\d+) -> (-?\d+)\): ([A-Za-z ]+) \([+-]\d+\))?$`)
+)
+
+// parseRoll recovers the structured roll from a dice roll message written before
+// rolls were stored with their entries. It returns nil if the message is not recognized.
+func parseRoll(msg string) *RollDetail {
+	msg = strings.TrimSpace(msg)
+	if m := fateRollPattern.FindStringSubmatch(msg); m != nil {
+		return parseFateRoll(m)
+	}
+	if m := oracleRollPattern.FindStringSubmatch(msg); m != nil {
+		return parseOracleRoll(m)
+	}
+	return nil
+}
+
+// parseOracleRoll builds an oracle roll from the submatches of oracleRollPattern.
+func parseOracleRoll(m []string) *RollDetail {
+	odds := chart.MatchOddsPrefix(m[3])
+	if len(odds) != 1 || odds[0].String() != m[3] {
+		return nil
+	}
+	d := &RollDetail{
+		Kind:     RollKindOracle,
+		Question: m[1],
+		Odds:     int(odds[0]),
+		Chaos:    atoi(m[2]),
+		Answer:   m[5],
+	}
+
+	roll, total, found := strings.Cut(m[4], " = ")
+	if !found {
+		if strings.Contains(d.Answer, ",") {
+			// An unmodified d6, e.g. "fifty fifty - 3: No, but"
+			d.Total = atoi(roll)
+			d.Dice = []int{d.Total}
+			d.Oracle = "yesandbut"
+			d.Target = 4
+			return d
+		}
+		// Otherwise assume a roll on the Fate Chart, the only oracle before others were added
+		d.Oracle = oracle.Default
+		d.Total = atoi(roll)
+		d.Dice = []int{d.Total}
+		d.RandomEvent = d.Total%11 == 0 && d.Total <= 99 && d.Total/11 <= d.Chaos
+		return d
+	}
+	// Dice joined by "+" followed by the signed modifier
+	i := strings.LastIndexAny(roll, "+-")
+	for _, v := range strings.Split(roll[:i], "+") {
+		d.Dice = append(d.Dice, atoi(v))
+	}
+	d.Modifier = atoi(roll[i:])
+	if _, limited, ok := strings.Cut(total, " -> "); ok {
+		total = limited
+	}
+	d.Total = atoi(total)
+	if len(d.Dice) == 2 {
+		d.Oracle = "fatecheck"
+		d.Target = 11
+		d.RandomEvent = d.Dice[0] == d.Dice[1] && d.Dice[0] <= d.Chaos
+	} else {
+		d.Oracle = "yesandbut"
+		d.Target = 4
+	}
+	return d
+}
+
+// parseFateRoll builds a 4dF roll from the submatches of fateRollPattern.
+func parseFateRoll(m []string) *RollDetail {
+	d := &RollDetail{
+		Kind:     RollKindFate,
+		Question: m[1],
+		Dice:     []int{atoi(m[2]), atoi(m[3]), atoi(m[4]), atoi(m[5])},
+	}
+	for _, v := range d.Dice {
+		d.Total += v
+	}
+	if m[6] != "" {
+		skill := atoi(m[6])
+		d.Skill = &skill
+		d.Total += skill
+	}
+	switch {
+	case m[7] != "":
+		difficulty := atoi(m[7])
+		d.Difficulty = &difficulty
+		d.Target = difficulty
+		d.Answer = m[8]
+	case m[13] != "":
+		difficulty := atoi(m[13])
+		d.Difficulty = &difficulty
+		d.OpponentDice = []int{atoi(m[9]), atoi(m[10]), atoi(m[11]), atoi(m[12])}
+		d.Target = atoi(m[14])
+		d.Answer = m[15]
+	}
+	return d
+}
+
+// atoi converts a string the roll patterns matched as a number, allowing a leading "+".
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(s, "+"))
+	return n
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestParseRoll(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want *RollDetail
+	}{
+		// Fate Chart questions as the oracle command logged them before rolls were stored
+		{
+			"Is the door locked? (C:5) -> likely - 42: Yes",
+			&RollDetail{Kind: RollKindOracle, Question: "Is the door locked?", Oracle: "fatechart",
+				Odds: int(chart.Likely), Chaos: 5, Dice: []int{42}, Total: 42, Answer: "Yes"},
+		},
+		{
+			"(C:3) -> fifty fifty - 97: Exceptional No",
+			&RollDetail{Kind: RollKindOracle, Oracle: "fatechart",
+				Odds: int(chart.FiftyFifty), Chaos: 3, Dice: []int{97}, Total: 97, Answer: "Exceptional No"},
+		},
+		{
+			"Is anyone home? (C:6) -> nearly certain - 33: Yes | Event: NPC Action: Attack Wealth (Bold Old, Abandon Home)",
+			&RollDetail{Kind: RollKindOracle, Question: "Is anyone home?", Oracle: "fatechart",
+				Odds: int(chart.NearlyCertain), Chaos: 6, Dice: []int{33}, Total: 33, Answer: "Yes", RandomEvent: true},
+		},
+		{
+			// Doubles above the chaos factor are no event
+			"Is it dark? (C:2) -> unlikely - 55: No",
+			&RollDetail{Kind: RollKindOracle, Question: "Is it dark?", Oracle: "fatechart",
+				Odds: int(chart.Unlikely), Chaos: 2, Dice: []int{55}, Total: 55, Answer: "No"},
+		},
+		// Rolls of the other oracles
+		{
+			"Does it hold? (C:5) -> fifty fifty - 3: No, but",
+			&RollDetail{Kind: RollKindOracle, Question: "Does it hold?", Oracle: "yesandbut",
+				Odds: int(chart.FiftyFifty), Chaos: 5, Dice: []int{3}, Total: 3, Target: 4, Answer: "No, but"},
+		},
+		{
+			"Does it hold? (C:5) -> likely - 4+1 = 5: Yes",
+			&RollDetail{Kind: RollKindOracle, Question: "Does it hold?", Oracle: "yesandbut",
+				Odds: int(chart.Likely), Chaos: 5, Dice: []int{4}, Modifier: 1, Total: 5, Target: 4, Answer: "Yes"},
+		},
+		{
+			"Is he armed? (C:4) -> unlikely - 3+3-1 = 5: No | Event: PC Negative: Betray Allies (Cruel Dark, Hide Truth)",
+			&RollDetail{Kind: RollKindOracle, Question: "Is he armed?", Oracle: "fatecheck",
+				Odds: int(chart.Unlikely), Chaos: 4, Dice: []int{3, 3}, Modifier: -1, Total: 5, Target: 11,
+				Answer: "No", RandomEvent: true},
+		},
+		// 4dF rolls as the fate command logged them
+		{
+			"4dF { 1, 0, -1, 1 } +1",
+			&RollDetail{Kind: RollKindFate, Dice: []int{1, 0, -1, 1}, Total: 1},
+		},
+		{
+			"Climb the wall | 4dF { -1, -1, 0, 1 } -1; skill 3 -> 2",
+			&RollDetail{Kind: RollKindFate, Question: "Climb the wall", Dice: []int{-1, -1, 0, 1},
+				Skill: intPtr(3), Total: 2},
+		},
+		{
+			"Pick the lock | 4dF { 1, 0, -1, 1 } +1; skill 2 -> 3 vs diff 2: Success (+1)",
+			&RollDetail{Kind: RollKindFate, Question: "Pick the lock", Dice: []int{1, 0, -1, 1},
+				Skill: intPtr(2), Difficulty: intPtr(2), Total: 3, Target: 2, Answer: "Success"},
+		},
+		{
+			"4dF { 0, 0, 0, 0 } +0 vs diff 0: Tie (+0)",
+			&RollDetail{Kind: RollKindFate, Dice: []int{0, 0, 0, 0},
+				Difficulty: intPtr(0), Answer: "Tie"},
+		},
+		{
+			"Arm wrestle | 4dF { 1, 1, 0, 1 } +3; skill 1 -> 4 vs Opponent ({ -1, 0, 0, -1 } -2; skill 2 -> 0): Success With Style (+4)",
+			&RollDetail{Kind: RollKindFate, Question: "Arm wrestle", Dice: []int{1, 1, 0, 1},
+				Skill: intPtr(1), Difficulty: intPtr(2), OpponentDice: []int{-1, 0, 0, -1},
+				Total: 4, Target: 0, Answer: "Success With Style"},
+		},
+		// Story text is not a roll
+		{"The door creaks open.", nil},
+		{"Is it locked? (C:5) -> maybe - 42: Yes", nil},
+	}
+	for _, tt := range tests {
+		if got := parseRoll(tt.msg); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseRoll(%q) = %+v, want %+v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestParseRollLimitedTotal(t *testing.T) {
+	// The d6 oracle limits its total to 1-6; the log shows the sum before the limit
+	want := &RollDetail{Kind: RollKindOracle, Question: "Is it over?", Oracle: "yesandbut",
+		Odds: int(chart.Certain), Chaos: 5, Dice: []int{6}, Modifier: 2, Total: 6, Target: 4, Answer: "Yes, and"}
+	msg := want.String()
+	if msg != "Is it over? (C:5) -> certain - 6+2 = 8 -> 6: Yes, and" {
+		t.Fatalf("String() = %q", msg)
+	}
+	if got := parseRoll(msg); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRoll(%q) = %+v, want %+v", msg, got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SceneRecord combines a scene with its CLI-specific details.
+type SceneRecord struct {
+	Scene
+	Detail SceneDetail
+}
+
+// GetScenes returns all scenes of a game in the order they were started, together with
+// their details. Scenes that have not been numbered yet are numbered first.
+func GetScenes(gameID uuid.UUID) ([]SceneRecord, error) {
+	if err := NumberScenes(gameID, false); err != nil {
+		return nil, err
+	}
+
+	var scenes []Scene
+	if err := db.GamesDB.Where("game_id = ?", gameID).Order("created_at ASC").Find(&scenes).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scenes: %w", err)
+	}
+	var details []SceneDetail
+	if err := db.GamesDB.Where("game_id = ?", gameID).Find(&details).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scene details: %w", err)
+	}
+	byScene := make(map[uuid.UUID]SceneDetail, len(details))
+	for _, d := range details {
+		byScene[d.SceneID] = d
+	}
+
+	records := make([]SceneRecord, 0, len(scenes))
+	for _, s := range scenes {
+		records = append(records, SceneRecord{Scene: s, Detail: byScene[s.ID]})
+	}
+	return records, nil
+}
+
+// FindScene returns the scene of a game with the given sequence number.
+func FindScene(gameID uuid.UUID, number int) (*SceneRecord, error) {
+	scenes, err := GetScenes(gameID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range scenes {
+		if scenes[i].Detail.Number == number {
+			return &scenes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("scene %d not found", number)
+}
+
+// NumberScenes assigns sequence numbers to the scenes of a game in the order they
+// were started. Scenes that already have a number keep it unless renumber is true,
+// in which case all scenes are numbered 1..n, closing any gaps.
+func NumberScenes(gameID uuid.UUID, renumber bool) error {
+	var scenes []Scene
+	if err := db.GamesDB.Where("game_id = ?", gameID).Order("created_at ASC").Find(&scenes).Error; err != nil {
+		return fmt.Errorf("failed to load scenes: %w", err)
+	}
+	var details []SceneDetail
+	if err := db.GamesDB.Where("game_id = ?", gameID).Find(&details).Error; err != nil {
+		return fmt.Errorf("failed to load scene details: %w", err)
+	}
+	byScene := make(map[uuid.UUID]SceneDetail, len(details))
+	next := 1
+	for _, d := range details {
+		byScene[d.SceneID] = d
+		if d.Number >= next {
+			next = d.Number + 1
+		}
+	}
+	if renumber {
+		next = 1
+	}
+
+	for _, s := range scenes {
+		d, ok := byScene[s.ID]
+		if ok && d.Number > 0 && !renumber {
+			continue
+		}
+		if !ok {
+			d = SceneDetail{SceneID: s.ID, GameID: gameID}
+		}
+		d.Number = next
+		next++
+		if err := db.GamesDB.Save(&d).Error; err != nil {
+			return fmt.Errorf("failed to number scene: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetSceneEntries returns the log entries recorded during a scene, oldest first.
+func GetSceneEntries(sceneID uuid.UUID) ([]LogEntry, error) {
+	var entries []LogEntry
+	q := db.GamesDB.Where("id IN (?)", db.GamesDB.Model(&LogEntryDetail{}).Select("log_entry_id").Where("scene_id = ?", sceneID))
+	if err := q.Order("created_at ASC").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load log entries: %w", err)
+	}
+	return entries, nil
+}
+
+// SceneEnd holds the answers of the end-of-scene bookkeeping.
+type SceneEnd struct {
+	Chaos             int8        // Chaos factor after the scene (internal range 0-8)
+	PCInControl       *bool       // Whether the PCs were in control, nil if not answered
+	NewThreads        []string    // Names of threads to add
+	ResolvedThreads   []Thread    // Threads to resolve
+	NewCharacters     []string    // Names of characters to add
+	RetiredCharacters []Character // Characters to retire
+	Summary           string
+	Msg               string // Message of the scene end log entry
+}
+
+// EndScene ends a scene of a game: it sets the chaos factor, updates the Threads and
+// Characters Lists, stores the summary, deactivates the scene and logs the scene end.
+// Everything is saved in one transaction, so a failure leaves the game unchanged.
+func EndScene(g *Game, s *Scene, end SceneEnd) error {
+	err := db.GamesDB.Transaction(func(tx *gorm.DB) error {
+		// Leave g alone until the transaction has committed
+		if err := tx.Model(&Game{}).Where("id = ?", g.ID).Update("chaos", end.Chaos).Error; err != nil {
+			return fmt.Errorf("failed to save chaos: %w", err)
+		}
+
+		for _, name := range end.NewThreads {
+			t := Thread{GameID: g.ID, Name: name, Weight: 1, Status: ThreadActive}
+			if err := tx.Create(&t).Error; err != nil {
+				return fmt.Errorf("failed to add thread: %w", err)
+			}
+		}
+		for i := range end.ResolvedThreads {
+			if err := tx.Model(&end.ResolvedThreads[i]).Update("status", ThreadResolved).Error; err != nil {
+				return fmt.Errorf("failed to update thread: %w", err)
+			}
+		}
+		for _, name := range end.NewCharacters {
+			c := Character{GameID: g.ID, Name: name, Weight: 1, Status: CharacterActive}
+			if err := tx.Create(&c).Error; err != nil {
+				return fmt.Errorf("failed to add character: %w", err)
+			}
+		}
+		for i := range end.RetiredCharacters {
+			if err := tx.Model(&end.RetiredCharacters[i]).Update("status", CharacterInactive).Error; err != nil {
+				return fmt.Errorf("failed to update character: %w", err)
+			}
+		}
+
+		if err := tx.Model(s).Update("is_active", false).Error; err != nil {
+			return fmt.Errorf("failed to end scene: %w", err)
+		}
+		detail := SceneDetail{SceneID: s.ID, GameID: g.ID}
+		if err := tx.FirstOrCreate(&detail, SceneDetail{SceneID: s.ID}).Error; err != nil {
+			return fmt.Errorf("failed to load scene details: %w", err)
+		}
+		if err := tx.Model(&detail).Updates(map[string]interface{}{
+			"summary":       end.Summary,
+			"pc_in_control": end.PCInControl,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to save scene details: %w", err)
+		}
+
+		if _, err := createLog(tx, g, LogEntryDetail{SceneID: &s.ID}, nil, LogTypeSceneEnd, end.Msg); err != nil {
+			return fmt.Errorf("failed to log scene end: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	g.SetChaos(end.Chaos)
+	return nil
+}
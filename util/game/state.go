@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DMXMax/mythic-cli/util/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// stateCurrentGame is the State entry holding the ID of the selected game.
+const stateCurrentGame = "current_game"
+
+// FindGame looks up a game by its exact name.
+func FindGame(name string) (*Game, error) {
+	g := &Game{}
+	if err := db.GamesDB.Where("name = ?", name).First(g).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("game '%s' not found", name)
+		}
+		return nil, fmt.Errorf("could not load game '%s': %w", name, err)
+	}
+	return g, nil
+}
+
+// SetCurrent makes g the current game and remembers the selection for later
+// invocations. A nil game clears the selection.
+func SetCurrent(g *Game) error {
+	Current = g
+	value := ""
+	if g != nil {
+		value = g.ID.String()
+	}
+	err := db.GamesDB.Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&State{Name: stateCurrentGame, Value: value}).Error
+	if err != nil {
+		return fmt.Errorf("failed to remember current game: %w", err)
+	}
+	return nil
+}
+
+// RestoreCurrent loads the game selected by an earlier invocation into Current.
+// Current stays nil if no game was selected or the selected game no longer exists.
+func RestoreCurrent() error {
+	var state State
+	if err := db.GamesDB.Where("name = ?", stateCurrentGame).Limit(1).Find(&state).Error; err != nil {
+		return fmt.Errorf("failed to load current game: %w", err)
+	}
+	if state.Value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(state.Value)
+	if err != nil {
+		return nil
+	}
+	g, err := LoadGame(id)
+	if err != nil {
+		return fmt.Errorf("failed to load current game: %w", err)
+	}
+	if g != nil {
+		Current = g
+	}
+	return nil
+}
+
+// LoadGame looks up a game by its ID. It returns nil if there is no such game.
+func LoadGame(id uuid.UUID) (*Game, error) {
+	var games []Game
+	if err := db.GamesDB.Where("id = ?", id).Limit(1).Find(&games).Error; err != nil {
+		return nil, err
+	}
+	if len(games) == 0 {
+		return nil, nil
+	}
+	return &games[0], nil
+}
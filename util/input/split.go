@@ -0,0 +1,105 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SplitArgs splits a command line into arguments the way a POSIX shell does:
+//   - whitespace separates arguments
+//   - single quotes keep everything up to the next single quote literally
+//   - double quotes group words; inside them a backslash escapes " and \
+//   - outside quotes a backslash escapes the next character
+//
+// Quoted empty strings such as "" produce empty arguments, and "--" is passed
+// through as-is so commands can use it to end flag parsing.
+// An unterminated quote or a trailing backslash is an error, with one exception
+// where a POSIX shell would fail: a single quote right after a letter or digit that
+// no later single quote could close is kept as an apostrophe, as in "Tom's horse".
+func SplitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool // current holds an argument, possibly empty
+		quote   rune // active quote character, 0 if none
+		escaped bool // previous character was an unquoted or double-quoted backslash
+		prev    rune // previous character
+	)
+
+	for i, r := range line {
+		apostrophe := r == '\'' && (unicode.IsLetter(prev) || unicode.IsDigit(prev)) &&
+			!strings.ContainsRune(line[i+1:], '\'')
+		prev = r
+		switch {
+		case escaped:
+			// Inside double quotes only \" and \\ are escapes
+			if quote == '"' && r != '"' && r != '\\' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case apostrophe:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("unfinished escape at end of line")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// QuoteArg quotes s so that SplitArgs returns it as a single argument.
+// Strings without spaces, quotes or backslashes are returned unchanged.
+func QuoteArg(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t'\"\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// JoinArgs joins arguments into a line that SplitArgs splits back into the same arguments.
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = QuoteArg(a)
+	}
+	return strings.Join(quoted, " ")
+}
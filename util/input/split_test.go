@@ -0,0 +1,57 @@
+package input
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`roll -o likely Is it locked?`, []string{"roll", "-o", "likely", "Is", "it", "locked?"}},
+		{`roll -o "nearly certain" Is it`, []string{"roll", "-o", "nearly certain", "Is", "it"}},
+		{`roll -o'very likely' Is it`, []string{"roll", "-overy likely", "Is", "it"}},
+		{`game info -g'Kat in Shadow'`, []string{"game", "info", "-gKat in Shadow"}},
+		{`gamelog add Tom's horse bolts`, []string{"gamelog", "add", "Tom's", "horse", "bolts"}},
+		{`roll Is it the guard's key?`, []string{"roll", "Is", "it", "the", "guard's", "key?"}},
+		{`'it''s'`, []string{"its"}},
+		{`rock 'n' roll`, []string{"rock", "n", "roll"}},
+		{`"it's" fine`, []string{"it's", "fine"}},
+		{`it\'s`, []string{"it's"}},
+		{`say "a \"b\" \c"`, []string{"say", `a "b" \c`}},
+		{`"" x`, []string{"", "x"}},
+		{`roll -- -o is part`, []string{"roll", "--", "-o", "is", "part"}},
+		{"  spaced \t out  ", []string{"spaced", "out"}},
+	}
+	for _, tt := range tests {
+		got, err := SplitArgs(tt.line)
+		if err != nil {
+			t.Errorf("SplitArgs(%q) failed: %v", tt.line, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSplitArgsErrors(t *testing.T) {
+	for _, line := range []string{`'unterminated`, `say "open`, `trailing \`} {
+		if got, err := SplitArgs(line); err == nil {
+			t.Errorf("SplitArgs(%q) = %q, want an error", line, got)
+		}
+	}
+}
+
+func TestJoinArgs(t *testing.T) {
+	args := []string{"roll", "Is it the guard's key?", `say "hi"`, "", `back\slash`}
+	got, err := SplitArgs(JoinArgs(args))
+	if err != nil {
+		t.Fatalf("SplitArgs(JoinArgs(%q)) failed: %v", args, err)
+	}
+	if !slices.Equal(got, args) {
+		t.Errorf("SplitArgs(JoinArgs(%q)) = %q", args, got)
+	}
+}
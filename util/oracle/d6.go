@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"math/rand/v2"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+// d6Answers maps a d6 result (index 0 is 1) to its answer.
+var d6Answers = [6]string{"No, and", "No", "No, but", "Yes, but", "Yes", "Yes, and"}
+
+// d6Mod shifts the d6 toward Yes or No depending on the odds.
+var d6Mod = map[chart.Odds]int{
+	chart.Impossible:       -2,
+	chart.NearlyImpossible: -2,
+	chart.VeryUnlikely:     -1,
+	chart.Unlikely:         -1,
+	chart.FiftyFifty:       0,
+	chart.Likely:           1,
+	chart.VeryLikely:       1,
+	chart.NearlyCertain:    2,
+	chart.Certain:          2,
+}
+
+// yesAndBut is a simple d6 oracle: 1-3 is No and 4-6 is Yes, qualified with "and" or "but".
+type yesAndBut struct{}
+
+func init() {
+	Register(yesAndBut{}, "d6", "yes and but", "yesno")
+}
+
+func (yesAndBut) Name() string { return "yesandbut" }
+
+func (yesAndBut) Description() string {
+	return "d6 yes/no with and/but (1 No, and; 2 No; 3 No, but; 4 Yes, but; 5 Yes; 6 Yes, and), shifted by the odds"
+}
+
+// Ask rolls a d6 shifted by up to 2 toward Yes or No depending on the odds.
+// The chaos factor is not used and no Random Events are triggered.
+func (y yesAndBut) Ask(odds chart.Odds, chaos int) *Result {
+	roll := rand.IntN(6) + 1
+	mod := d6Mod[odds]
+	total := max(min(roll+mod, 6), 1)
+	return &Result{
+		Oracle:   y.Name(),
+		Odds:     odds,
+		Chaos:    clampChaos(chaos),
+		Dice:     []int{roll},
+		Modifier: mod,
+		Total:    total,
+		Target:   4,
+		Answer:   d6Answers[total-1],
+	}
+}
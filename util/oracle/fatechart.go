@@ -0,0 +1,33 @@
+package oracle
+
+import "github.com/DMXMax/mge/chart"
+
+// fateChart is the Mythic Fate Chart: a d100 roll against the chart value for the odds and chaos factor.
+type fateChart struct{}
+
+func init() {
+	Register(fateChart{}, "chart", "fate chart")
+}
+
+func (fateChart) Name() string { return "fatechart" }
+
+func (fateChart) Description() string {
+	return "Mythic Fate Chart (d100 against the chart value for the odds and chaos factor)"
+}
+
+// Ask rolls on the Fate Chart. A double (11, 22, ... 99) whose digit is at or below
+// the chaos factor triggers a Random Event.
+func (f fateChart) Ask(odds chart.Odds, chaos int) *Result {
+	chaos = clampChaos(chaos)
+	r := chart.FateChart.RollOdds(odds, chart.ChaosUserToInternal(chaos))
+	return &Result{
+		Oracle:      f.Name(),
+		Odds:        odds,
+		Chaos:       chaos,
+		Dice:        []int{r.Roll},
+		Total:       r.Roll,
+		Target:      r.Odds,
+		Answer:      r.Text,
+		RandomEvent: r.Roll%11 == 0 && r.Roll <= 99 && r.Roll/11 <= chaos,
+	}
+}
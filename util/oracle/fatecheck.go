@@ -0,0 +1,68 @@
+package oracle
+
+import (
+	"math/rand/v2"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+// fateCheckOddsMod holds the Mythic 2e Fate Check modifier for each odds value.
+var fateCheckOddsMod = map[chart.Odds]int{
+	chart.Impossible:       -5,
+	chart.NearlyImpossible: -4,
+	chart.VeryUnlikely:     -2,
+	chart.Unlikely:         -1,
+	chart.FiftyFifty:       0,
+	chart.Likely:           1,
+	chart.VeryLikely:       2,
+	chart.NearlyCertain:    4,
+	chart.Certain:          5,
+}
+
+// fateCheckChaosMod holds the Mythic 2e Fate Check modifier for each
+// user-facing chaos factor (index 0 is chaos 1).
+var fateCheckChaosMod = [9]int{-5, -4, -2, -1, 0, 1, 2, 4, 5}
+
+// fateCheck is the Mythic 2e Fate Check: 2d10 plus odds and chaos modifiers.
+type fateCheck struct{}
+
+func init() {
+	Register(fateCheck{}, "check", "fate check")
+}
+
+func (fateCheck) Name() string { return "fatecheck" }
+
+func (fateCheck) Description() string {
+	return "Mythic 2e Fate Check (2d10 plus odds and chaos modifiers, Yes on 11+)"
+}
+
+// Ask rolls 2d10, adds the odds and chaos modifiers and reads the total:
+// 11 or more is a Yes, 18 or more an Exceptional Yes and 4 or less an Exceptional No.
+// Both dice showing the same number at or below the chaos factor trigger a Random Event.
+func (f fateCheck) Ask(odds chart.Odds, chaos int) *Result {
+	chaos = clampChaos(chaos)
+	d1, d2 := rand.IntN(10)+1, rand.IntN(10)+1
+
+	r := &Result{
+		Oracle:      f.Name(),
+		Odds:        odds,
+		Chaos:       chaos,
+		Dice:        []int{d1, d2},
+		Modifier:    fateCheckOddsMod[odds] + fateCheckChaosMod[chaos-1],
+		Target:      11,
+		RandomEvent: d1 == d2 && d1 <= chaos,
+	}
+	r.Total = d1 + d2 + r.Modifier
+
+	switch {
+	case r.Total >= 18:
+		r.Answer = "Exceptional Yes"
+	case r.Total >= 11:
+		r.Answer = "Yes"
+	case r.Total <= 4:
+		r.Answer = "Exceptional No"
+	default:
+		r.Answer = "No"
+	}
+	return r
+}
@@ -0,0 +1,57 @@
+package oracle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+// NormalizeOdds normalizes odds input by lowercasing, trimming, and standardizing variants.
+// It handles common separators (hyphens, underscores, em dashes) and converts them to spaces,
+// and recognizes common numeric forms like "50/50" and converts them to "fifty fifty".
+func NormalizeOdds(s string) string {
+	s = strings.TrimSpace(strings.ToLower(s))
+	// normalize common separators to spaces
+	s = strings.ReplaceAll(s, "—", " ") // em dash
+	s = strings.ReplaceAll(s, "-", " ")
+	s = strings.ReplaceAll(s, "_", " ")
+	// collapse all whitespace sequences
+	s = strings.Join(strings.Fields(s), " ")
+	// common alias for fifty fifty
+	switch s {
+	case "50/50", "50-50", "50 50", "50 50":
+		return "fifty fifty"
+	}
+	return s
+}
+
+// ParseOdds parses odds given by number (0-8) or by name. Names may be abbreviated
+// to any unique prefix, e.g. "v l" for "very likely".
+func ParseOdds(s string) (chart.Odds, error) {
+	normalized := NormalizeOdds(s)
+
+	// Try numeric odds first
+	if parsed, err := strconv.ParseInt(normalized, 10, 8); err == nil {
+		if parsed < 0 || parsed > 8 {
+			return 0, fmt.Errorf("odds must be between 0 and 8")
+		}
+		return chart.Odds(parsed), nil
+	}
+
+	// Not a number, try to match it to a name. MatchOddsPrefix returns every
+	// odds value when nothing matches.
+	matches := chart.MatchOddsPrefix(normalized)
+	if normalized == "" || len(matches) == len(chart.OddsStrList) {
+		return 0, fmt.Errorf("invalid odds: '%s'", s)
+	}
+	if len(matches) != 1 {
+		names := make([]string, len(matches))
+		for i, m := range matches {
+			names[i] = m.String()
+		}
+		return 0, fmt.Errorf("multiple possible odds for '%s' (did you mean %s?)", s, strings.Join(names, " or "))
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,118 @@
+// Package oracle provides the yes/no oracles used to answer questions with the roll command.
+// Oracles register themselves by name so games can choose one and single rolls can override it.
+package oracle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+// Default is the name of the oracle used when a game has not chosen one.
+const Default = "fatechart"
+
+// Oracle answers yes/no questions.
+type Oracle interface {
+	// Name returns the name used to select the oracle.
+	Name() string
+	// Description returns a one-line description of the oracle.
+	Description() string
+	// Ask answers a question with the given odds and user-facing chaos factor (1-9).
+	Ask(odds chart.Odds, chaos int) *Result
+}
+
+// Result is the structured outcome of asking an oracle.
+type Result struct {
+	Oracle      string     `json:"oracle"`       // Name of the oracle that produced the result
+	Odds        chart.Odds `json:"-"`            // Odds of the question (encoded by name)
+	Chaos       int        `json:"chaos"`        // User-facing chaos factor (1-9)
+	Dice        []int      `json:"dice"`         // Individual dice rolled
+	Modifier    int        `json:"modifier"`     // Modifier added to the dice, if the oracle uses one
+	Total       int        `json:"total"`        // Value the answer was read from
+	Target      int        `json:"target"`       // Value the total was compared against, if the oracle uses one
+	Answer      string     `json:"answer"`       // Answer, e.g. "Yes" or "Exceptional No"
+	RandomEvent bool       `json:"random_event"` // Whether the roll triggers a Random Event
+}
+
+// MarshalJSON encodes the result with its odds given by name, e.g. "very likely".
+func (r *Result) MarshalJSON() ([]byte, error) {
+	type result Result
+	return json.Marshal(struct {
+		Odds string `json:"odds"`
+		*result
+	}{r.Odds.String(), (*result)(r)})
+}
+
+// String renders the result for display and the game log,
+// e.g. "likely - 42: Yes" or "likely - 7+6+1 = 14: Yes". A total the oracle
+// limited to its range is shown after the sum, e.g. "certain - 6+2 = 8 -> 6: Yes, and".
+func (r *Result) String() string {
+	var roll string
+	switch {
+	case len(r.Dice) > 1 || r.Modifier != 0:
+		dice := make([]string, len(r.Dice))
+		sum := r.Modifier
+		for i, d := range r.Dice {
+			dice[i] = fmt.Sprint(d)
+			sum += d
+		}
+		roll = fmt.Sprintf("%s%+d = %d", strings.Join(dice, "+"), r.Modifier, sum)
+		if sum != r.Total {
+			roll = fmt.Sprintf("%s -> %d", roll, r.Total)
+		}
+	default:
+		roll = fmt.Sprint(r.Total)
+	}
+	return fmt.Sprintf("%s - %s: %s", r.Odds, roll, r.Answer)
+}
+
+var (
+	oracles = map[string]Oracle{}
+	aliases = map[string]string{}
+)
+
+// Register makes an oracle available under its name and any aliases.
+// Registering a name twice replaces the earlier oracle.
+func Register(o Oracle, alias ...string) {
+	oracles[o.Name()] = o
+	for _, a := range alias {
+		aliases[normalize(a)] = o.Name()
+	}
+}
+
+// Names returns the names of all registered oracles in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(oracles))
+	for name := range oracles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Find returns the oracle registered under a name or alias.
+// Case, spaces, hyphens and underscores are ignored, so "Fate Check" finds "fatecheck".
+func Find(name string) (Oracle, error) {
+	n := normalize(name)
+	if o, ok := oracles[n]; ok {
+		return o, nil
+	}
+	if o, ok := oracles[aliases[n]]; ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("unknown oracle: %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// normalize lowercases a name and removes separators.
+func normalize(name string) string {
+	n := strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(n)
+}
+
+// clampChaos limits a user-facing chaos factor to 1-9.
+func clampChaos(chaos int) int {
+	return max(min(chaos, chart.MaxChaosUser), chart.MinChaosUser)
+}
@@ -0,0 +1,27 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+func TestResultString(t *testing.T) {
+	tests := []struct {
+		r    Result
+		want string
+	}{
+		{Result{Odds: chart.Likely, Dice: []int{42}, Total: 42, Answer: "Yes"}, "likely - 42: Yes"},
+		{Result{Odds: chart.FiftyFifty, Dice: []int{3}, Total: 3, Answer: "No, but"}, "fifty fifty - 3: No, but"},
+		{Result{Odds: chart.Likely, Dice: []int{3}, Modifier: 1, Total: 4, Answer: "Yes, but"}, "likely - 3+1 = 4: Yes, but"},
+		{Result{Odds: chart.Unlikely, Dice: []int{4, 2}, Modifier: -1, Total: 5, Answer: "No"}, "unlikely - 4+2-1 = 5: No"},
+		// A total limited to the oracle's range follows the sum
+		{Result{Odds: chart.Certain, Dice: []int{6}, Modifier: 2, Total: 6, Answer: "Yes, and"}, "certain - 6+2 = 8 -> 6: Yes, and"},
+		{Result{Odds: chart.Impossible, Dice: []int{1}, Modifier: -2, Total: 1, Answer: "No, and"}, "impossible - 1-2 = -1 -> 1: No, and"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
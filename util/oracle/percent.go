@@ -0,0 +1,64 @@
+package oracle
+
+import (
+	"math/rand/v2"
+
+	"github.com/DMXMax/mge/chart"
+)
+
+// likelihoodPercent holds the chance of a Yes for each odds value.
+var likelihoodPercent = map[chart.Odds]int{
+	chart.Impossible:       5,
+	chart.NearlyImpossible: 10,
+	chart.VeryUnlikely:     20,
+	chart.Unlikely:         35,
+	chart.FiftyFifty:       50,
+	chart.Likely:           65,
+	chart.VeryLikely:       80,
+	chart.NearlyCertain:    90,
+	chart.Certain:          95,
+}
+
+// percent is a likelihood oracle: a d100 roll against a fixed percentage for the odds.
+type percent struct{}
+
+func init() {
+	Register(percent{}, "likelihood", "percentage")
+}
+
+func (percent) Name() string { return "percent" }
+
+func (percent) Description() string {
+	return "Likelihood percent (d100 against a fixed chance per odds, from 5% impossible to 95% certain)"
+}
+
+// Ask rolls a d100 against the likelihood for the odds, ignoring the chaos factor.
+// The lowest fifth of the Yes range is an Exceptional Yes and the highest fifth of
+// the No range an Exceptional No. Doubles at or below the chaos factor trigger a
+// Random Event, as on the Fate Chart.
+func (p percent) Ask(odds chart.Odds, chaos int) *Result {
+	chaos = clampChaos(chaos)
+	target := likelihoodPercent[odds]
+	roll := rand.IntN(100) + 1
+
+	r := &Result{
+		Oracle:      p.Name(),
+		Odds:        odds,
+		Chaos:       chaos,
+		Dice:        []int{roll},
+		Total:       roll,
+		Target:      target,
+		RandomEvent: roll%11 == 0 && roll <= 99 && roll/11 <= chaos,
+	}
+	switch {
+	case roll <= target/5:
+		r.Answer = "Exceptional Yes"
+	case roll <= target:
+		r.Answer = "Yes"
+	case roll > 100-(100-target)/5:
+		r.Answer = "Exceptional No"
+	default:
+		r.Answer = "No"
+	}
+	return r
+}
@@ -0,0 +1,258 @@
+// Package output selects between human-readable text and machine-readable JSON output
+// and provides the JSON forms of the CLI's data.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/DMXMax/mge/chart"
+	"github.com/DMXMax/mge/util"
+	gdb "github.com/DMXMax/mythic-cli/util/game"
+	"github.com/google/uuid"
+)
+
+// Output formats
+const (
+	Text = "text" // Human-readable text (default)
+	JSON = "json" // One JSON document per command
+)
+
+var format = Text
+
+// Set selects the output format.
+func Set(f string) error {
+	switch f {
+	case Text, JSON:
+		format = f
+		return nil
+	}
+	return fmt.Errorf("unknown output format: %q (use %s or %s)", f, Text, JSON)
+}
+
+// Format returns the selected output format.
+func Format() string {
+	return format
+}
+
+// IsJSON reports whether commands should print JSON.
+func IsJSON() bool {
+	return format == JSON
+}
+
+// Print writes v to w as indented JSON followed by a newline.
+func Print(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
+
+// Entry is the JSON form of a log entry.
+type Entry struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      int        `json:"type"`
+	TypeName  string     `json:"type_name"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+	SceneID   *uuid.UUID `json:"scene_id,omitempty"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Roll      *Roll      `json:"roll,omitempty"`
+}
+
+// Entries converts log entries to their JSON form, including the scene and parent entry
+// they are linked to and the structured roll of dice roll entries.
+func Entries(entries []gdb.LogEntry) ([]Entry, error) {
+	ids := make([]uuid.UUID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	details, err := gdb.GetLogEntryDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+	rolls, err := gdb.GetRollDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Entry, len(entries))
+	for i, e := range entries {
+		out[i] = Entry{
+			ID:        e.ID,
+			Type:      e.Type,
+			TypeName:  gdb.LogTypeNames[e.Type],
+			Message:   e.Msg,
+			CreatedAt: e.CreatedAt,
+		}
+		if d, ok := details[e.ID]; ok {
+			out[i].SceneID = d.SceneID
+			out[i].ParentID = d.ParentID
+		}
+		if r, ok := rolls[e.ID]; ok {
+			out[i].Roll = NewRoll(&r)
+		}
+	}
+	return out, nil
+}
+
+// Roll is the JSON form of a roll. Oracle questions fill in the oracle, odds and
+// chaos; 4dF rolls the skill, difficulty and opponent's dice.
+type Roll struct {
+	Kind         string `json:"kind"`
+	Question     string `json:"question"`
+	Oracle       string `json:"oracle,omitempty"`
+	Odds         string `json:"odds,omitempty"`
+	Chaos        int    `json:"chaos,omitempty"`
+	Dice         []int  `json:"dice"`
+	Modifier     int    `json:"modifier"`
+	Total        int    `json:"total"`
+	Target       int    `json:"target"`
+	Answer       string `json:"answer,omitempty"`
+	RandomEvent  bool   `json:"random_event"`
+	Skill        *int   `json:"skill,omitempty"`
+	Difficulty   *int   `json:"difficulty,omitempty"`
+	OpponentDice []int  `json:"opponent_dice,omitempty"`
+	Shifts       *int   `json:"shifts,omitempty"`
+}
+
+// NewRoll converts a roll to its JSON form.
+func NewRoll(d *gdb.RollDetail) *Roll {
+	out := &Roll{
+		Kind:         d.Kind,
+		Question:     d.Question,
+		Oracle:       d.Oracle,
+		Dice:         d.Dice,
+		Modifier:     d.Modifier,
+		Total:        d.Total,
+		Target:       d.Target,
+		Answer:       d.Answer,
+		RandomEvent:  d.RandomEvent,
+		Skill:        d.Skill,
+		Difficulty:   d.Difficulty,
+		OpponentDice: d.OpponentDice,
+	}
+	if d.Kind == gdb.RollKindOracle {
+		out.Odds = chart.Odds(d.Odds).String()
+		out.Chaos = d.Chaos
+	} else if d.Difficulty != nil {
+		shifts := d.Shifts()
+		out.Shifts = &shifts
+	}
+	return out
+}
+
+// Event is the JSON form of a random event.
+type Event struct {
+	Focus       string     `json:"focus"`
+	Target      string     `json:"target,omitempty"`
+	Action      string     `json:"action"`
+	Subject     string     `json:"subject"`
+	Descriptors []string   `json:"descriptors"`
+	Actions     []string   `json:"actions"`
+	Text        string     `json:"text"`
+	EntryID     *uuid.UUID `json:"entry_id,omitempty"`
+}
+
+// NewEvent converts a random event to its JSON form. entry is the log entry
+// recording the event, or nil if it was not logged.
+func NewEvent(e *gdb.ResolvedEvent, entry *gdb.LogEntry) *Event {
+	out := &Event{
+		Focus:       util.EventText[e.Focus],
+		Target:      e.Target,
+		Action:      e.Action,
+		Subject:     e.Subject,
+		Descriptors: e.Meaning.Descriptors,
+		Actions:     e.Meaning.Actions,
+		Text:        e.String(),
+	}
+	if entry != nil {
+		out.EntryID = &entry.ID
+	}
+	return out
+}
+
+// ListItem is the JSON form of a thread or character.
+type ListItem struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Weight      int       `json:"weight"`
+	Status      string    `json:"status"`
+	Notes       string    `json:"notes,omitempty"`
+}
+
+// Threads converts threads to their JSON form.
+func Threads(threads []gdb.Thread) []ListItem {
+	out := make([]ListItem, len(threads))
+	for i, t := range threads {
+		out[i] = ListItem{ID: t.ID, Name: t.Name, Description: t.Description, Weight: t.Weight, Status: t.Status}
+	}
+	return out
+}
+
+// Characters converts characters to their JSON form.
+func Characters(characters []gdb.Character) []ListItem {
+	out := make([]ListItem, len(characters))
+	for i, c := range characters {
+		out[i] = ListItem{ID: c.ID, Name: c.Name, Description: c.Description, Weight: c.Weight, Status: c.Status, Notes: c.Notes}
+	}
+	return out
+}
+
+// Game is the JSON form of a game's summary.
+type Game struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Chaos     int       `json:"chaos"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewGame converts a game to its JSON summary, with the user-facing chaos factor (1-9).
+func NewGame(g *gdb.Game) Game {
+	return Game{
+		ID:        g.ID,
+		Name:      g.Name,
+		Chaos:     chart.ChaosInternalToUser(int(g.Chaos)),
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+}
+
+// Scene is the JSON form of a scene.
+type Scene struct {
+	ID              uuid.UUID `json:"id"`
+	Number          int       `json:"number"`
+	Type            string    `json:"type"`
+	ExpectedConcept string    `json:"expected_concept"`
+	ChaosDieRoll    int       `json:"chaos_die_roll"`
+	Active          bool      `json:"active"`
+	Adjustment      string    `json:"adjustment,omitempty"`
+	Event           string    `json:"event,omitempty"`
+	Summary         string    `json:"summary,omitempty"`
+	PCInControl     *bool     `json:"pc_in_control,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// NewScene converts a scene and its details to JSON form.
+func NewScene(s gdb.SceneRecord) *Scene {
+	return &Scene{
+		ID:              s.ID,
+		Number:          s.Detail.Number,
+		Type:            s.Type,
+		ExpectedConcept: s.ExpectedConcept,
+		ChaosDieRoll:    s.ChaosDieRoll,
+		Active:          s.IsActive,
+		Adjustment:      s.Detail.Adjustment,
+		Event:           s.Detail.Event,
+		Summary:         s.Detail.Summary,
+		PCInControl:     s.Detail.PCInControl,
+		CreatedAt:       s.CreatedAt,
+	}
+}
@@ -0,0 +1,164 @@
+// Package shortcut manages the user's shell aliases and macros.
+// They are stored in ~/.mythic-db/shortcuts.json and expanded by the shell before a command is dispatched.
+package shortcut
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DMXMax/mythic-cli/util/input"
+)
+
+// maxDepth limits how deeply aliases and macros may refer to each other.
+const maxDepth = 10
+
+// Shortcuts holds the user's aliases and macros.
+type Shortcuts struct {
+	// Aliases maps a name to a command line that replaces it.
+	Aliases map[string]string `json:"aliases"`
+	// Macros maps a name to the command lines it runs in order.
+	Macros map[string][]string `json:"macros"`
+}
+
+// Path returns the location of the shortcuts file.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".mythic-db", "shortcuts.json"), nil
+}
+
+// Load reads the shortcuts file. A missing file yields empty shortcuts.
+func Load() (*Shortcuts, error) {
+	s := &Shortcuts{Aliases: map[string]string{}, Macros: map[string][]string{}}
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if s.Aliases == nil {
+		s.Aliases = map[string]string{}
+	}
+	if s.Macros == nil {
+		s.Macros = map[string][]string{}
+	}
+	return s, nil
+}
+
+// Save writes the shortcuts file, creating its directory if needed.
+func (s *Shortcuts) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode shortcuts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Expand replaces a leading alias or macro name in args and returns the resulting
+// commands, each as its own argument list. Commands that are neither are returned as-is.
+//
+// Aliases and macros may use positional parameters: $1 to $9 are replaced by the
+// arguments that follow the name, and an argument of exactly $@ by all of them.
+// An alias that uses no parameters gets the arguments appended instead.
+func (s *Shortcuts) Expand(args []string) ([][]string, error) {
+	return s.expand(args, 0)
+}
+
+func (s *Shortcuts) expand(args []string, depth int) ([][]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%s: aliases and macros nested too deeply", args[0])
+	}
+
+	name, params := args[0], args[1:]
+	if line, ok := s.Aliases[name]; ok {
+		expanded, used, err := substitute(name, line, params)
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			expanded = append(expanded, params...)
+		}
+		return s.expand(expanded, depth+1)
+	}
+
+	if lines, ok := s.Macros[name]; ok {
+		var commands [][]string
+		for _, line := range lines {
+			expanded, _, err := substitute(name, line, params)
+			if err != nil {
+				return nil, err
+			}
+			sub, err := s.expand(expanded, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, sub...)
+		}
+		return commands, nil
+	}
+
+	return [][]string{args}, nil
+}
+
+// substitute splits line into arguments and replaces its positional parameters
+// with params. It reports whether any parameter was used.
+func substitute(name, line string, params []string) ([]string, bool, error) {
+	args, err := input.SplitArgs(line)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	used := false
+	var out []string
+	for _, arg := range args {
+		if arg == "$@" {
+			out = append(out, params...)
+			used = true
+			continue
+		}
+		var b strings.Builder
+		for i := 0; i < len(arg); i++ {
+			if arg[i] == '$' && i+1 < len(arg) && arg[i+1] >= '1' && arg[i+1] <= '9' {
+				n, _ := strconv.Atoi(arg[i+1 : i+2])
+				if n > len(params) {
+					return nil, false, fmt.Errorf("%s: missing argument $%d", name, n)
+				}
+				b.WriteString(params[n-1])
+				used = true
+				i++
+				continue
+			}
+			b.WriteByte(arg[i])
+		}
+		out = append(out, b.String())
+	}
+	return out, used, nil
+}